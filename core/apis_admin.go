@@ -16,6 +16,8 @@ package core
 
 import (
 	"application/core/model"
+	"application/driven/storage"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -193,8 +195,8 @@ func (a appAdmin) DeleteConfig(id string, claims *tokenauth.Claims) error {
 	return nil
 }
 
-func (a appAdmin) AddWebtoolsBlackList(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error {
-	err := a.app.storage.AddWebtoolsBlacklistData(dataSourceIDs, dataCalendarIDs, dataOriginatingCalendarIDs)
+func (a appAdmin) AddWebtoolsBlackList(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error {
+	err := a.app.storage.AddWebtoolsBlacklistData(orgID, appID, dataSourceIDs, dataCalendarIDs, dataOriginatingCalendarIDs)
 	if err != nil {
 		return nil
 	}
@@ -202,17 +204,17 @@ func (a appAdmin) AddWebtoolsBlackList(dataSourceIDs []string, dataCalendarIDs [
 	return nil
 }
 
-func (a appAdmin) GetWebtoolsBlackList() ([]model.Blacklist, error) {
+func (a appAdmin) GetWebtoolsBlackList(orgID string, appID string) ([]model.Blacklist, error) {
 
-	blacklist, err := a.app.storage.FindWebtoolsBlacklistData(nil)
+	blacklist, err := a.app.storage.FindWebtoolsBlacklistData(nil, orgID, appID)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeConfig, nil, err)
 	}
 	return blacklist, nil
 }
 
-func (a appAdmin) RemoveWebtoolsBlackList(sourceIds []string, calendarids []string, originatingCalendarIdsList []string) error {
-	err := a.app.storage.RemoveWebtoolsBlacklistData(sourceIds, calendarids, originatingCalendarIdsList)
+func (a appAdmin) RemoveWebtoolsBlackList(orgID string, appID string, sourceIds []string, calendarids []string, originatingCalendarIdsList []string) error {
+	err := a.app.storage.RemoveWebtoolsBlacklistData(orgID, appID, sourceIds, calendarids, originatingCalendarIdsList)
 	if err != nil {
 		return nil
 	}
@@ -220,7 +222,7 @@ func (a appAdmin) RemoveWebtoolsBlackList(sourceIds []string, calendarids []stri
 	return nil
 }
 
-func (a appAdmin) GetEventsItems(source *string, status *string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error) {
+func (a appAdmin) GetEventsItems(orgID string, appID string, source *string, status *string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error) {
 
 	//status
 	var statuses *[]string
@@ -228,17 +230,87 @@ func (a appAdmin) GetEventsItems(source *string, status *string, dataSourceEvent
 		statuses = &[]string{*status}
 	}
 
-	events, err := a.app.storage.FindLegacyEventItems(nil, source, statuses, dataSourceEventID, calendarID, originatingCalendarID)
+	events, err := a.app.storage.FindLegacyEventItems(nil, orgID, appID, source, statuses, dataSourceEventID, calendarID, originatingCalendarID)
 	if err != nil {
 		return nil, err
 	}
 	return events, nil
 }
 
-func (a appAdmin) GetEventsSummary() (*model.EventsSummary, error) {
+// SearchArchivedEvents searches the archived events
+func (a appAdmin) SearchArchivedEvents(query model.LegacyEventsQuery) ([]model.ArchivedLegacyEventItem, error) {
+	return a.app.storage.SearchArchivedLegacyEvents(query)
+}
+
+// GetEventHistory gets the history of an event
+func (a appAdmin) GetEventHistory(orgID string, appID string, id string) ([]model.LegacyEventHistoryItem, error) {
+	return a.app.storage.FindLegacyEventHistory(orgID, appID, id)
+}
+
+// GetWebToolsSyncReports gets the most recent web tools sync reports
+func (a appAdmin) GetWebToolsSyncReports(orgID string, appID string, limit int64) ([]model.WebToolsSyncReport, error) {
+	return a.app.storage.FindWebToolsSyncReports(orgID, appID, limit)
+}
+
+// GetEventsModerationQueue gets the events which wait for moderation, the oldest come first
+func (a appAdmin) GetEventsModerationQueue(orgID string, appID string) ([]model.LegacyEventItem, error) {
+	return a.app.storage.FindPendingLegacyEventItems(nil, orgID, appID, nil)
+}
+
+// ModerateEvent approves or rejects the event which waits for moderation, nil if there is no such event
+func (a appAdmin) ModerateEvent(orgID string, appID string, id string, approved bool, reason string, moderatorID string) (*model.LegacyEventItem, error) {
+	var moderated *model.LegacyEventItem
+	err := a.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		moderated = nil
+		items, err := a.app.storage.FindPendingLegacyEventItems(context, orgID, appID, &id)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		previous := items[0]
+		current := previous
+		now := time.Now()
+		moderation := model.LegacyEventModeration{Status: model.LegacyEventModerationApproved, ModeratorID: moderatorID, DateModerated: &now}
+		current.Status = model.LegacyEventStatus{Name: model.LegacyEventStatusValid}
+		if !approved {
+			moderation.Status = model.LegacyEventModerationRejected
+			moderation.Reason = reason
+			current.Status = model.LegacyEventStatus{Name: model.LegacyEventStatusRejected}
+		}
+		current.Moderation = &moderation
+
+		historyItem := newLegacyEventHistoryItem(&previous, &current, current.SyncProcessSource, moderatorID, now)
+		current.Version = historyItem.Version
+		err = a.app.storage.InsertLegacyEventsHistory(context, []model.LegacyEventHistoryItem{*historyItem})
+		if err != nil {
+			return err
+		}
+
+		err = a.app.storage.UpdateLegacyEventModeration(context, current)
+		if err != nil {
+			return err
+		}
+		moderated = &current
+		return nil
+	}, 60000)
+	if err != nil {
+		return nil, err
+	}
+	return moderated, nil
+}
+
+// ReconcileEventsBB compares the events BB legacy events with the gateway ones and optionally backfills the missing ones
+func (a appAdmin) ReconcileEventsBB(orgID string, appID string, backfillGateway bool, backfillEventsBB bool) (*model.LegacyEventsReconciliation, error) {
+	return a.app.eventsLogic.reconcileEventsBB(orgID, appID, backfillGateway, backfillEventsBB)
+}
+
+func (a appAdmin) GetEventsSummary(orgID string, appID string) (*model.EventsSummary, error) {
 	//get all items
 	statuses := []string{"valid", "ignored"}
-	allEvents, err := a.app.storage.FindLegacyEventItems(nil, nil, &statuses, nil, nil, nil)
+	allEvents, err := a.app.storage.FindLegacyEventItems(nil, orgID, appID, nil, &statuses, nil, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +419,7 @@ func (a appAdmin) GetEventsSummary() (*model.EventsSummary, error) {
 		TpsAPI: model.TPsSource{Count: ignoredTpsAPICount}}
 
 	//blacklists
-	blacklist, err := a.app.storage.FindWebtoolsBlacklistData(nil)
+	blacklist, err := a.app.storage.FindWebtoolsBlacklistData(nil, orgID, appID)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +434,164 @@ func (a appAdmin) GetEventsSummary() (*model.EventsSummary, error) {
 	return &summary, nil
 }
 
+// GetBuildingAliases gets the building aliases used by the buildings search and the event locations
+func (a appAdmin) GetBuildingAliases() ([]model.BuildingAlias, error) {
+	return a.app.storage.FindBuildingAliases()
+}
+
+// CreateBuildingAlias adds an alias of a building of the current buildings dataset, the alias is stored normalized like the searched text.
+// The search gets the alias from the storage listener.
+func (a appAdmin) CreateBuildingAlias(alias string, buildingID string) (*model.BuildingAlias, error) {
+	alias = strings.Join(searchWords(alias), " ")
+	if len(alias) == 0 {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeBuildingAlias, &logutils.FieldArgs{"alias": alias}).SetStatus(string(logutils.StatusInvalid))
+	}
+
+	dataset, err := a.app.Client.GetBuildingsDataset()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeBuildingsDataset, nil, err)
+	}
+	found := false
+	for _, building := range dataset.Buildings {
+		if buildingKey(building) == buildingID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeBuilding, &logutils.FieldArgs{"id": buildingID}).SetStatus(string(logutils.StatusMissing))
+	}
+
+	aliases, err := a.app.storage.FindBuildingAliases()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range aliases {
+		if existing.Alias == alias {
+			return nil, errors.ErrorData(logutils.StatusFound, model.TypeBuildingAlias, &logutils.FieldArgs{"alias": alias, "building_id": existing.BuildingID}).SetStatus(string(logutils.StatusFound))
+		}
+	}
+
+	item := model.BuildingAlias{ID: uuid.NewString(), Alias: alias, BuildingID: buildingID, DateCreated: time.Now().UTC()}
+	err = a.app.storage.InsertBuildingAlias(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteBuildingAlias deletes a building alias, the search drops it by the storage listener
+func (a appAdmin) DeleteBuildingAlias(id string) error {
+	return a.app.storage.DeleteBuildingAlias(id)
+}
+
+// GetBuildingFeatureCatalog gets the catalog of the building features
+func (a appAdmin) GetBuildingFeatureCatalog() ([]model.AppBuildingFeature, error) {
+	return a.app.storage.LoadAppBuildingFeatures()
+}
+
+// CreateBuildingFeatureCatalogEntry adds a feature to the catalog, the running cache is updated by the storage listener
+func (a appAdmin) CreateBuildingFeatureCatalogEntry(item model.AppBuildingFeature) (*model.AppBuildingFeature, error) {
+	item.CampusCode = strings.TrimSpace(item.CampusCode)
+	item.AppCode = strings.TrimSpace(item.AppCode)
+	if len(item.CampusCode) == 0 || len(item.AppCode) == 0 {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeAppBuildingFeature, &logutils.FieldArgs{"campus_code": item.CampusCode, "app_code": item.AppCode}).SetStatus(string(logutils.StatusInvalid))
+	}
+
+	catalog, err := a.app.storage.LoadAppBuildingFeatures()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range catalog {
+		if existing.CampusCode == item.CampusCode {
+			return nil, errors.ErrorData(logutils.StatusFound, model.TypeAppBuildingFeature, &logutils.FieldArgs{"campus_code": item.CampusCode}).SetStatus(string(logutils.StatusFound))
+		}
+	}
+
+	err = a.app.storage.InsertAppBuildingFeature(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateBuildingFeatureCatalogEntry updates the feature of the catalog with the campus code
+func (a appAdmin) UpdateBuildingFeatureCatalogEntry(item model.AppBuildingFeature) error {
+	item.AppCode = strings.TrimSpace(item.AppCode)
+	if len(item.AppCode) == 0 {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeAppBuildingFeature, &logutils.FieldArgs{"campus_code": item.CampusCode, "app_code": item.AppCode}).SetStatus(string(logutils.StatusInvalid))
+	}
+	return a.app.storage.UpdateAppBuildingFeature(item)
+}
+
+// DeleteBuildingFeatureCatalogEntry deletes the feature of the catalog with the campus code
+func (a appAdmin) DeleteBuildingFeatureCatalogEntry(campusCode string) error {
+	return a.app.storage.DeleteAppBuildingFeature(campusCode)
+}
+
+// GetFeatureLocations gets the managed feature locations, all of them or the ones of a building
+func (a appAdmin) GetFeatureLocations(buildingNumber *string) ([]model.ManagedFeatureLocation, error) {
+	return a.app.storage.FindManagedFeatureLocations(buildingNumber)
+}
+
+// CreateFeatureLocation sets where a feature is in a building of the current buildings dataset, no floors hide the feature
+func (a appAdmin) CreateFeatureLocation(buildingNumber string, key string, name string, floors []string) (*model.ManagedFeatureLocation, error) {
+	key = strings.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeManagedFeatureLocation, &logutils.FieldArgs{"key": key}).SetStatus(string(logutils.StatusInvalid))
+	}
+
+	dataset, err := a.app.Client.GetBuildingsDataset()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeBuildingsDataset, nil, err)
+	}
+	found := false
+	for _, building := range dataset.Buildings {
+		if building.Number == buildingNumber {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeBuilding, &logutils.FieldArgs{"number": buildingNumber}).SetStatus(string(logutils.StatusMissing))
+	}
+
+	locations, err := a.app.storage.FindManagedFeatureLocations(&buildingNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range locations {
+		if existing.Key == key {
+			return nil, errors.ErrorData(logutils.StatusFound, model.TypeManagedFeatureLocation, &logutils.FieldArgs{"building_number": buildingNumber, "key": key, "id": existing.ID}).SetStatus(string(logutils.StatusFound))
+		}
+	}
+
+	if floors == nil {
+		floors = []string{}
+	}
+	item := model.ManagedFeatureLocation{ID: uuid.NewString(), BuildingNumber: buildingNumber, Key: key, Name: name, Floors: floors,
+		DateCreated: time.Now().UTC()}
+	err = a.app.storage.InsertManagedFeatureLocation(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateFeatureLocation updates the name and the floors of a managed feature location
+func (a appAdmin) UpdateFeatureLocation(id string, name string, floors []string) error {
+	if floors == nil {
+		floors = []string{}
+	}
+	now := time.Now().UTC()
+	return a.app.storage.UpdateManagedFeatureLocation(model.ManagedFeatureLocation{ID: id, Name: name, Floors: floors, DateUpdated: &now})
+}
+
+// DeleteFeatureLocation deletes a managed feature location, the feature is back to its location given by the wayfinding data
+func (a appAdmin) DeleteFeatureLocation(id string) error {
+	return a.app.storage.DeleteManagedFeatureLocation(id)
+}
+
 // newAppAdmin creates new appAdmin
 func newAppAdmin(app *Application) appAdmin {
 	return appAdmin{app: app}
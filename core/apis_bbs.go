@@ -91,11 +91,20 @@ func (a appBBs) DeleteAppointment(uin string, providerid int, sourceid string, a
 	return ret, nil
 }
 
-func (a appBBs) GetLegacyEvents() ([]model.LegacyEvent, error) {
+// GetLegacyEvents gives the valid legacy events of the web tools feed org and app.
+// The building blocks share one api key, so they cannot ask for another org or app.
+func (a appBBs) GetLegacyEvents(audiences []string, attendanceModes []string) ([]model.LegacyEvent, error) {
+	orgID, appID := a.app.webToolsRegistration.OrgID, a.app.webToolsRegistration.AppID
+
+	if len(audiences) > 0 || len(attendanceModes) > 0 {
+		//get the valid which match the filters
+		query := model.LegacyEventsQuery{OrgID: orgID, AppID: appID, Audiences: audiences, AttendanceModes: attendanceModes}
+		return a.app.shared.searchLegacyEvents(query)
+	}
 
 	//get all valid
 	status := "valid"
-	leEvents, err := a.app.storage.FindLegacyEvents(nil, &status)
+	leEvents, err := a.app.storage.FindLegacyEvents(orgID, appID, nil, &status)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +112,12 @@ func (a appBBs) GetLegacyEvents() ([]model.LegacyEvent, error) {
 	return leEvents, nil
 }
 
+// SearchLegacyEvents searches the valid legacy events of the web tools feed org and app
+func (a appBBs) SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error) {
+	query.OrgID, query.AppID = a.app.webToolsRegistration.OrgID, a.app.webToolsRegistration.AppID
+	return a.app.shared.searchLegacyEvents(query)
+}
+
 // newAppBBs creates new appBBs
 func newAppBBs(app *Application) appBBs {
 	appBB := appBBs{app: app}
@@ -19,10 +19,18 @@ import (
 	"application/driven/uiucadapters"
 	"encoding/json"
 	"os"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/rokwireutils"
 )
 
+// legacyEventsFeedMaxLimit is the maximum and the default number of the events in the RSS and Atom feeds
+const legacyEventsFeedMaxLimit = 200
+
 // appClient contains client implementations
 type appClient struct {
 	app                *Application
@@ -90,9 +98,13 @@ func (a appClient) GetBuilding(bldgID string, adaOnly bool, latitude float64, lo
 
 }
 
-func (a appClient) GetEntrance(bldgID string, adaOnly bool, latitude float64, longitude float64) (*model.Entrance, error) {
+func (a appClient) GetEntrance(bldgID string, adaOnly bool, latitude float64, longitude float64) (*model.NearestEntrance, error) {
 	conf, _ := a.app.GetEnvConfigs()
-	retData, err := a.LocationAdapter.GetEntrance(bldgID, adaOnly, latitude, longitude, conf)
+	primaryEntranceID, err := a.getPrimaryEntranceID(bldgID)
+	if err != nil {
+		return nil, err
+	}
+	retData, err := a.LocationAdapter.GetEntrance(bldgID, adaOnly, latitude, longitude, primaryEntranceID, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +112,22 @@ func (a appClient) GetEntrance(bldgID string, adaOnly bool, latitude float64, lo
 
 }
 
+// getPrimaryEntranceID gives the configured primary entrance of the building, empty when there is none
+func (a appClient) getPrimaryEntranceID(bldgID string) (string, error) {
+	config, err := a.app.storage.FindConfig(model.ConfigTypeBuildingEntrances, rokwireutils.AllApps, rokwireutils.AllOrgs)
+	if err != nil {
+		return "", errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
+	}
+	if config == nil {
+		return "", nil
+	}
+	entrancesConfig, err := model.GetConfigData[model.BuildingEntrancesConfigData](*config)
+	if err != nil {
+		return "", err
+	}
+	return entrancesConfig.PrimaryEntrance(bldgID), nil
+}
+
 func (a appClient) GetBuildings() (*[]model.Building, error) {
 	retData, err := a.getCachedBuildings()
 	if err != nil {
@@ -108,25 +136,27 @@ func (a appClient) GetBuildings() (*[]model.Building, error) {
 	return retData, nil
 }
 
-func (a appClient) getCachedBuildings() (*[]model.Building, error) {
-	conf, _ := a.app.GetEnvConfigs()
-	crntDate := time.Now()
-	diff := crntDate.Sub(a.app.CampusBuildings.LoadDate)
-	if diff.Hours() < 24 {
-		retData := a.app.CampusBuildings.Buildings
-		return &retData, nil
+// GetBuildingsDataset gets the current campus buildings dataset
+func (a appClient) GetBuildingsDataset() (*model.BuildingsDataset, error) {
+	dataset := a.app.buildingsLogic.getDataset()
+	if dataset != nil {
+		return dataset, nil
 	}
+	//the dataset has not been loaded yet
+	return a.app.buildingsLogic.refresh()
+}
 
-	retData, err := a.LocationAdapter.GetBuildings(conf)
+func (a appClient) getCachedBuildings() (*[]model.Building, error) {
+	//the buildings dataset is refreshed in the background
+	dataset, err := a.GetBuildingsDataset()
 	if err != nil {
 		return nil, err
 	}
-	//any time we call out to get the list of buildings, we need to cache the results
-	a.app.CampusBuildings.Buildings = *retData
-	a.app.CampusBuildings.LoadDate = time.Now()
-	return retData, nil
+	retData := dataset.Buildings
+	return &retData, nil
 }
 
+// SearchBuildings gets the buildings which contain the text in their name or short name, SearchBuildingsRanked tolerates typos and ranks them
 func (a appClient) SearchBuildings(bldgName string, returnCompact bool) (*map[string]any, error) {
 	allbuildings, err := a.getCachedBuildings()
 	if err != nil {
@@ -134,18 +164,110 @@ func (a appClient) SearchBuildings(bldgName string, returnCompact bool) (*map[st
 	}
 	var retData = make(map[string]any)
 	for _, v := range *allbuildings {
-		if strings.Contains(strings.ToLower(v.Name), strings.ToLower(bldgName)) || strings.Contains(strings.ToLower(v.ShortName), strings.ToLower(bldgName)) {
-			if returnCompact {
-				crntBldg := model.CompactBuilding{Name: v.Name, FullAddress: v.FullAddress, Latitude: v.Latitude, Longitude: v.Longitude, ImageURL: v.ImageURL, Number: v.Number, ShortName: v.ShortName}
-				retData[v.Name] = crntBldg
-			} else {
-				retData[v.Name] = v
-			}
+		if !strings.Contains(strings.ToLower(v.Name), strings.ToLower(bldgName)) && !strings.Contains(strings.ToLower(v.ShortName), strings.ToLower(bldgName)) {
+			continue
+		}
+		if returnCompact {
+			crntBldg := model.CompactBuilding{Name: v.Name, FullAddress: v.FullAddress, Latitude: v.Latitude, Longitude: v.Longitude, ImageURL: v.ImageURL, Number: v.Number, ShortName: v.ShortName}
+			retData[v.Name] = crntBldg
+		} else {
+			retData[v.Name] = v
 		}
 	}
 	return &retData, nil
 }
 
+// SearchBuildingsRanked gets the buildings matching the text by their name, short name, number, address or alias, the most relevant come first
+func (a appClient) SearchBuildingsRanked(text string, limit int) ([]model.BuildingSearchResult, error) {
+	if limit <= 0 {
+		limit = buildingsSearchDefaultLimit
+	}
+	return a.searchBuildings(text, limit)
+}
+
+// GetBuildingsNear gets the buildings within the radius of the point which have the feature, the closest come first
+func (a appClient) GetBuildingsNear(latitude float64, longitude float64, radius float64, feature string, adaOnly bool, limit int) ([]model.NearbyBuilding, error) {
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return findNearbyBuildings(dataset.Buildings, latitude, longitude, radius, feature, adaOnly, limit), nil
+}
+
+func (a appClient) GetMapBuildings(bbox *model.BoundingBox) ([]model.MapBuilding, error) {
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return mapBuildings(dataset.Buildings, a.app.buildingsLogic.footprints, bbox), nil
+}
+
+func (a appClient) GetMapEntrances(bbox *model.BoundingBox, adaOnly bool) ([]model.MapEntrance, error) {
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return mapEntrances(dataset.Buildings, bbox, adaOnly), nil
+}
+
+func (a appClient) GetMapFeatures(bbox *model.BoundingBox, feature string) ([]model.MapFeature, error) {
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return mapFeatures(dataset.Buildings, a.app.getAppBuildingFeatures(), bbox, feature), nil
+}
+
+// SearchBuildingFeatures gets the buildings which have the features matching the text
+func (a appClient) SearchBuildingFeatures(text string) ([]model.BuildingFeatureSearchResult, error) {
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return searchBuildingFeatures(dataset.Buildings, a.app.getAppBuildingFeatures(), text), nil
+}
+
+func (a appClient) GetWalkingRoute(fromLatitude float64, fromLongitude float64, toLatitude float64, toLongitude float64, bldgID string, accessibleOnly bool) (*model.WalkingRoute, error) {
+	if len(bldgID) == 0 {
+		target := walkingTarget{latitude: toLatitude, longitude: toLongitude}
+		return a.app.routingLogic.findWalkingRoute(fromLatitude, fromLongitude, nil, []walkingTarget{target}, accessibleOnly), nil
+	}
+
+	dataset, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(dataset.Buildings, func(b model.Building) bool { return b.ID == bldgID || b.Number == bldgID })
+	if index < 0 {
+		return nil, nil
+	}
+	building := dataset.Buildings[index]
+
+	//the route ends at the entrance with the shortest walk
+	targets := []walkingTarget{}
+	for i, entrance := range building.Entrances {
+		if entrance.Available && (!accessibleOnly || entrance.ADACompliant) && (entrance.Latitude != 0 || entrance.Longitude != 0) {
+			targets = append(targets, walkingTarget{latitude: entrance.Latitude, longitude: entrance.Longitude, entrance: &building.Entrances[i]})
+		}
+	}
+	if len(targets) == 0 {
+		if accessibleOnly || (building.Latitude == 0 && building.Longitude == 0) {
+			return nil, nil
+		}
+		targets = append(targets, walkingTarget{latitude: building.Latitude, longitude: building.Longitude})
+	}
+	return a.app.routingLogic.findWalkingRoute(fromLatitude, fromLongitude, &building, targets, accessibleOnly), nil
+}
+
+func (a appClient) searchBuildings(text string, limit int) ([]model.BuildingSearchResult, error) {
+	//make sure the buildings dataset has been loaded
+	_, err := a.GetBuildingsDataset()
+	if err != nil {
+		return nil, err
+	}
+	return a.app.buildingsLogic.searchBuildings(text, limit)
+}
+
 func (a appClient) GetContactInfo(uin string, accessToken string, mode string) (*model.Person, int, error) {
 	conf, _ := a.app.GetEnvConfigs()
 	retData, statuscode, err := a.ContactAdapter.GetContactInformation(uin, accessToken, mode, conf)
@@ -199,13 +321,14 @@ func (a appClient) GetSuccessTeam(uin string, unitid string, accesstoken string)
 
 }
 
-func (a appClient) GetFloorPlan(buildingnumber string, floornumber string, markers string, highlites string) (*model.FloorPlan, int, error) {
+func (a appClient) GetFloorPlan(buildingnumber string, floornumber string, markers string, highlites string, room string) (*model.FloorPlan, int, error) {
 	conf, _ := a.app.GetEnvConfigs()
 
 	retData, err := a.LocationAdapter.GetFloorPlan(buildingnumber, floornumber, markers, highlites, a.app.FloorPlanWrapper.Markup, conf)
 	if err != nil {
 		return nil, 500, err
 	}
+	highlightFloorPlanRoom(retData, room)
 	return retData, 200, nil
 }
 
@@ -319,6 +442,36 @@ func (a appClient) shouldRefreshAtEightAM(currentTime time.Time, lastLoadTime ti
 	return false
 }
 
+// GetImage gets an event image kept by the gateway image storage
+func (a appClient) GetImage(key string) ([]byte, string, error) {
+	return a.app.imageAdapter.LoadImage(key)
+}
+
+// SearchLegacyEvents searches the valid legacy events
+func (a appClient) SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error) {
+	return a.app.shared.searchLegacyEvents(query)
+}
+
+// GetLegacyEventsNear gets the valid legacy events within radius meters of a point, the closest come first
+func (a appClient) GetLegacyEventsNear(latitude float64, longitude float64, radius float64, query model.LegacyEventsQuery) ([]model.NearbyLegacyEvent, error) {
+	status := "valid"
+	query.Status = &status
+	return a.app.storage.FindLegacyEventsNear(latitude, longitude, radius, query)
+}
+
+// GetLegacyEventsFeed gets the valid legacy events of the web tools feed org and app for the RSS and Atom feeds, the upcoming events by default
+func (a appClient) GetLegacyEventsFeed(query model.LegacyEventsQuery) ([]model.LegacyEvent, error) {
+	query.OrgID, query.AppID = a.app.webToolsRegistration.OrgID, a.app.webToolsRegistration.AppID
+	if query.From == nil {
+		now := time.Now()
+		query.From = &now
+	}
+	if query.Limit <= 0 || query.Limit > legacyEventsFeedMaxLimit {
+		query.Limit = legacyEventsFeedMaxLimit
+	}
+	return a.app.shared.searchLegacyEvents(query)
+}
+
 // newAppClient creates new appClient
 func newAppClient(app *Application) appClient {
 
@@ -337,7 +490,7 @@ func newAppClient(app *Application) appClient {
 	client.ContactAdapter = uiucadapters.NewUIUCContactAdapter()
 	client.LaundryAdapter = uiucadapters.NewCSCLaundryAdapter(laundryAssets)
 	client.Courseadapter = uiucadapters.NewCourseAdapter()
-	client.LocationAdapter = uiucadapters.NewUIUCWayFinding(&app.AppBLdgFeatures)
+	client.LocationAdapter = uiucadapters.NewUIUCWayFinding(app.getAppBuildingFeatures)
 	client.SuccessTeamAdapter = uiucadapters.NewSuccessTeamAdapter()
 	client.CrowdMeterAdapter = uiucadapters.NewUIUCCrowdMeterAdapter()
 	return client
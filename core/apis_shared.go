@@ -42,3 +42,10 @@ func (a appShared) getBuildingFeatures() ([]model.AppBuildingFeature, error) {
 func (a appShared) getFloorPlanMarkup() (*model.FloorPlanMarkup, error) {
 	return a.app.storage.LoadFloorPlanMarkup()
 }
+
+// searchLegacyEvents searches the valid legacy events
+func (a appShared) searchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error) {
+	status := "valid"
+	query.Status = &status
+	return a.app.storage.SearchLegacyEvents(query)
+}
@@ -16,7 +16,9 @@ package core
 
 import (
 	"application/core/model"
+	"application/driven/storage"
 	"strings"
+	"time"
 )
 
 // appTPS contains BB implementations
@@ -36,12 +38,123 @@ func (a appTPS) CreateEvents(event []model.LegacyEventItem) ([]model.LegacyEvent
 		a.app.logger.Errorf("error on ignoring legacy events - %s", err)
 		return nil, err
 	}
-	return a.app.storage.InsertLegacyEvents(nil, modifiedLegacyEvents)
+
+	//the events of the moderated accounts wait for moderation
+	err = a.setModeration(modifiedLegacyEvents)
+	if err != nil {
+		a.app.logger.Errorf("error on setting legacy events moderation - %s", err)
+		return nil, err
+	}
+
+	var createdEvents []model.LegacyEventItem
+	now := time.Now()
+	err = a.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		history := make([]model.LegacyEventHistoryItem, len(modifiedLegacyEvents))
+		for i, event := range modifiedLegacyEvents {
+			actor := ""
+			if event.CreateInfo != nil {
+				actor = event.CreateInfo.AccountID
+			}
+			historyItem := newLegacyEventHistoryItem(nil, &event, event.SyncProcessSource, actor, now)
+			modifiedLegacyEvents[i].Version = historyItem.Version
+			history[i] = *historyItem
+		}
+		err := a.app.storage.InsertLegacyEventsHistory(context, history)
+		if err != nil {
+			return err
+		}
+
+		createdEvents, err = a.app.storage.InsertLegacyEvents(context, modifiedLegacyEvents)
+		return err
+	}, 60000)
+	if err != nil {
+		return nil, err
+	}
+	return createdEvents, nil
 }
 
 // DeleteEvents deletes legacy events by ids and creator
-func (a appTPS) DeleteEvents(ids []string, accountID string) error {
-	return a.app.storage.DeleteLegacyEventsByIDsAndCreator(nil, ids, accountID)
+func (a appTPS) DeleteEvents(orgID string, appID string, ids []string, accountID string) error {
+	now := time.Now()
+	return a.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		events, err := a.app.storage.FindLegacyEventItemsByIDsAndCreator(context, orgID, appID, ids, accountID)
+		if err != nil {
+			return err
+		}
+
+		history := make([]model.LegacyEventHistoryItem, len(events))
+		for i, event := range events {
+			history[i] = *newLegacyEventHistoryItem(&event, nil, event.SyncProcessSource, accountID, now)
+		}
+		err = a.app.storage.InsertLegacyEventsHistory(context, history)
+		if err != nil {
+			return err
+		}
+
+		return a.app.storage.DeleteLegacyEventsByIDsAndCreator(context, orgID, appID, ids, accountID)
+	}, 60000)
+}
+
+// GetEvents gets the events created by the account in the query
+func (a appTPS) GetEvents(query model.LegacyEventsQuery) ([]model.LegacyEventItem, error) {
+	return a.app.storage.SearchLegacyEventItems(query)
+}
+
+// GetEvent gets the event created by the account, nil if the account has not created such event
+func (a appTPS) GetEvent(orgID string, appID string, id string, accountID string) (*model.LegacyEventItem, error) {
+	items, err := a.app.storage.FindLegacyEventItemsByIDsAndCreator(nil, orgID, appID, []string{id}, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return &items[0], nil
+}
+
+// GetEventsModeration gets the moderation of the events created by the account, the events of not moderated accounts are approved
+func (a appTPS) GetEventsModeration(orgID string, appID string, accountID string) ([]model.TPSEventModeration, error) {
+	items, err := a.app.storage.FindLegacyEventItemsByIDsAndCreator(nil, orgID, appID, nil, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.TPSEventModeration, len(items))
+	for i, item := range items {
+		moderation := model.LegacyEventModeration{Status: model.LegacyEventModerationApproved}
+		if item.Moderation != nil {
+			moderation = *item.Moderation
+		}
+		result[i] = model.TPSEventModeration{ID: item.Item.ID, Title: item.Item.Title, Moderation: moderation}
+	}
+	return result, nil
+}
+
+// setModeration makes the events of the accounts moderated in the org and app wait for moderation
+func (a appTPS) setModeration(events []model.LegacyEventItem) error {
+	for i, event := range events {
+		if event.CreateInfo == nil {
+			continue
+		}
+
+		config, err := a.app.storage.FindConfig(model.ConfigTypeTPSModeration, event.AppID, event.OrgID)
+		if err != nil {
+			return err
+		}
+		if config == nil {
+			continue
+		}
+		moderationConfig, err := model.GetConfigData[model.TPSModerationConfigData](*config)
+		if err != nil {
+			return err
+		}
+
+		if moderationConfig.IsModerated(event.CreateInfo.AccountID) {
+			events[i].Status = model.LegacyEventStatus{Name: model.LegacyEventStatusPending}
+			events[i].Moderation = &model.LegacyEventModeration{Status: model.LegacyEventModerationPending}
+		}
+	}
+	return nil
 }
 
 // ignore or modify legacy events
@@ -74,11 +187,18 @@ func (a appTPS) modifyLegacyEventsList(legacyEvents []model.LegacyEventItem) ([]
 		//modify some categories
 		if newCategory, ok := categoryMap[lowerCategory]; ok {
 			currentWte.Item.Category = newCategory
+			currentWte.CategoryMapping = &model.LegacyEventCategoryMapping{Original: category, Mapped: newCategory}
 			a.app.logger.Infof("modifying event category from %s to %s", category, newCategory)
 
 			modified++
 		}
 
+		//the parsed dates and location are used for querying
+		setLegacyEventQueryFields(&currentWte)
+
+		//keep only safe html in the description
+		setLegacyEventDescription(&currentWte.Item)
+
 		//add it to the modified list
 		modifiedList = append(modifiedList, currentWte)
 	}
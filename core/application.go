@@ -16,6 +16,7 @@ package core
 
 import (
 	"application/core/model"
+	"sync"
 	"time"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
@@ -29,6 +30,21 @@ type storageListener struct {
 	model.DefaultStorageListener
 }
 
+// OnBuildingFeaturesUpdated notifies that the building features catalog has changed
+func (s *storageListener) OnBuildingFeaturesUpdated() {
+	s.app.buildingsLogic.onBuildingFeaturesUpdated()
+}
+
+// OnManagedFeatureLocationsUpdated notifies that the managed feature locations have changed
+func (s *storageListener) OnManagedFeatureLocationsUpdated() {
+	s.app.buildingsLogic.loadFeatureLocations()
+}
+
+// OnBuildingAliasesUpdated notifies that the building aliases have changed
+func (s *storageListener) OnBuildingAliasesUpdated() {
+	s.app.buildingsLogic.loadAliases()
+}
+
 // OnExampleUpdated notifies that the example collection has changed
 func (s *storageListener) OnExampleUpdated() {
 	s.app.logger.Infof("OnExampleUpdated")
@@ -49,10 +65,11 @@ type Application struct {
 	System  System  // expose to the drivers adapters
 	shared  Shared
 
-	CampusBuildings  model.CachedBuildings               //caches a list of all campus building data
-	AppBLdgFeatures  map[string]model.AppBuildingFeature //caches the configured set of building features
-	FloorPlanWrapper model.FloorPlanMarkup               //caches the floor plan markup
-	CrowdDataCache   model.CachedCrowdData               //caches crowd data for locations
+	CampusBuildings     *model.BuildingsDataset             //caches the current campus buildings dataset
+	appBldgFeatures     map[string]model.AppBuildingFeature //caches the configured set of building features
+	appBldgFeaturesLock *sync.RWMutex
+	FloorPlanWrapper    model.FloorPlanMarkup //caches the floor plan markup
+	CrowdDataCache      model.CachedCrowdData //caches crowd data for locations
 
 	AppointmentAdapters map[string]Appointments //expose to the different vendor specific appointment adapters
 
@@ -60,12 +77,27 @@ type Application struct {
 
 	storage Storage
 
-	eventsBBAdapter EventsBBAdapter
-	imageAdapter    ImageAdapter
-	geoBBAdapter    GeoAdapter
+	eventsBBAdapter    EventsBBAdapter
+	imageAdapter       ImageAdapter
+	geoBBAdapter       GeoAdapter
+	webToolsFeed       WebToolsFeed
+	sidearmFeed        SidearmFeed
+	walkingPaths       WalkingPaths
+	buildingFootprints BuildingFootprints
+
+	webToolsRegistration model.WebToolsFeedRegistration
+	eventsRetention      model.LegacyEventsRetention
+	//process again the images stored before the renditions were introduced
+	imageRenditionsBackfill bool
 
 	//events logic
 	eventsLogic eventsLogic
+
+	//buildings logic
+	buildingsLogic buildingsLogic
+
+	//routing logic
+	routingLogic routingLogic
 }
 
 // Start starts the core part of the application
@@ -79,6 +111,16 @@ func (a *Application) Start() error {
 		return err
 	}
 
+	err = a.buildingsLogic.start()
+	if err != nil {
+		return err
+	}
+
+	err = a.routingLogic.start()
+	if err != nil {
+		return err
+	}
+
 	//no error
 	return nil
 }
@@ -96,25 +138,59 @@ func (a *Application) GetEnvConfigs() (*model.EnvConfigData, error) {
 	return model.GetConfigData[model.EnvConfigData](*config)
 }
 
+// getAppBuildingFeatures gives the cached building features catalog by campus code, it must not be modified
+func (a *Application) getAppBuildingFeatures() map[string]model.AppBuildingFeature {
+	a.appBldgFeaturesLock.RLock()
+	defer a.appBldgFeaturesLock.RUnlock()
+
+	return a.appBldgFeatures
+}
+
+// setAppBuildingFeatures replaces the cached building features catalog by campus code.
+// The map is replaced instead of modified, so the catalog given to the readers stays unchanged
+func (a *Application) setAppBuildingFeatures(features []model.AppBuildingFeature) {
+	catalog := make(map[string]model.AppBuildingFeature, len(features))
+	for _, bf := range features {
+		catalog[bf.CampusCode] = bf
+	}
+
+	a.appBldgFeaturesLock.Lock()
+	defer a.appBldgFeaturesLock.Unlock()
+
+	a.appBldgFeatures = catalog
+}
+
 // NewApplication creates new Application
 func NewApplication(version string, build string,
 	storage Storage,
 	eventsBBAdapter EventsBBAdapter,
 	imageAdapter ImageAdapter,
+	imageRenditionsBackfill bool,
 	geoBBAdapter GeoAdapter,
+	webToolsFeed WebToolsFeed,
+	webToolsRegistration model.WebToolsFeedRegistration,
+	sidearmFeed SidearmFeed,
+	walkingPaths WalkingPaths,
+	buildingFootprints BuildingFootprints,
 	appntAdapters map[string]Appointments,
+	eventsRetention model.LegacyEventsRetention,
 	logger *logs.Logger) *Application {
-	application := Application{version: version, build: build, storage: storage, eventsBBAdapter: eventsBBAdapter, imageAdapter: imageAdapter, logger: logger, AppointmentAdapters: appntAdapters}
+	application := Application{version: version, build: build, storage: storage, eventsBBAdapter: eventsBBAdapter, imageAdapter: imageAdapter, logger: logger, AppointmentAdapters: appntAdapters,
+		webToolsFeed: webToolsFeed, webToolsRegistration: webToolsRegistration, sidearmFeed: sidearmFeed, walkingPaths: walkingPaths, buildingFootprints: buildingFootprints, eventsRetention: eventsRetention,
+		imageRenditionsBackfill: imageRenditionsBackfill, appBldgFeaturesLock: &sync.RWMutex{}}
 
 	//add the drivers ports/interfaces
 	application.Default = newAppDefault(&application)
-	application.Client = newAppClient(&application)
+	client := newAppClient(&application)
+	application.Client = client
 	application.Admin = newAppAdmin(&application)
 	application.BBs = newAppBBs(&application)
 	application.TPS = newAppTPS(&application)
 	application.System = newAppSystem(&application)
 	application.shared = newAppShared(&application)
-	application.eventsLogic = newAppEventsLogic(&application, eventsBBAdapter, geoBBAdapter, *logger)
+	application.eventsLogic = newAppEventsLogic(&application, eventsBBAdapter, geoBBAdapter, webToolsFeed, sidearmFeed, *logger)
+	application.buildingsLogic = newBuildingsLogic(&application, client.LocationAdapter, buildingFootprints, *logger)
+	application.routingLogic = newRoutingLogic(&application, walkingPaths, *logger)
 
 	fmpw, fmerr := application.shared.getFloorPlanMarkup()
 	if fmerr != nil {
@@ -128,15 +204,11 @@ func NewApplication(version string, build string,
 	if blderr != nil {
 
 	}
-	application.AppBLdgFeatures = make(map[string]model.AppBuildingFeature)
-	for _, bf := range bldfeatures {
-		application.AppBLdgFeatures[bf.CampusCode] = bf
-	}
+	application.setAppBuildingFeatures(bldfeatures)
 
 	//	_, err := application.Client.GetBuildings()
 	//	if err != nil {
 	//set to one day ago to force a retry and refresh
-	application.CampusBuildings.LoadDate = time.Now().AddDate(0, 0, -1)
 	application.CrowdDataCache.LoadDate = time.Now().AddDate(0, 0, -1)
 	//}
 
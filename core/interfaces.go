@@ -36,8 +36,9 @@ type Client interface {
 	InitServiceRequest(machineid string) (*model.MachineRequestDetail, error)
 	SubmitServiceRequest(machineID string, problemCode string, comments string, firstname string, lastname string, phone string, email string) (*model.ServiceRequestResult, error)
 	GetBuilding(bldgID string, adaOnly bool, latitude float64, longitude float64) (*model.Building, error)
-	GetEntrance(bldgID string, adaOnly bool, latitude float64, longitude float64) (*model.Entrance, error)
+	GetEntrance(bldgID string, adaOnly bool, latitude float64, longitude float64) (*model.NearestEntrance, error)
 	GetBuildings() (*[]model.Building, error)
+	GetBuildingsDataset() (*model.BuildingsDataset, error)
 	GetContactInfo(uin string, accessToken string, mode string) (*model.Person, int, error)
 	GetGiesCourses(uin string, accessToken string) (*[]model.GiesCourse, int, error)
 	GetStudentCourses(uin string, termid string, accessToken string) (*[]model.Course, int, error)
@@ -45,11 +46,22 @@ type Client interface {
 	GetSuccessTeam(uin string, unitid string, accessToken string) (*model.SuccessTeam, int, error)
 	GetPrimaryCareProvider(uin string, accessToken string) (*[]model.SuccessTeamMember, int, error)
 	GetAcademicAdvisors(uin string, unitid string, accessToken string) (*[]model.SuccessTeamMember, int, error)
-	GetFloorPlan(buildingnumber string, floornumber string, markers string, highlites string) (*model.FloorPlan, int, error)
+	GetFloorPlan(buildingnumber string, floornumber string, markers string, highlites string, room string) (*model.FloorPlan, int, error)
 	SearchBuildings(bldgName string, returnCompact bool) (*map[string]any, error)
+	SearchBuildingsRanked(text string, limit int) ([]model.BuildingSearchResult, error)
+	GetBuildingsNear(latitude float64, longitude float64, radius float64, feature string, adaOnly bool, limit int) ([]model.NearbyBuilding, error)
+	GetMapBuildings(bbox *model.BoundingBox) ([]model.MapBuilding, error)
+	GetMapEntrances(bbox *model.BoundingBox, adaOnly bool) ([]model.MapEntrance, error)
+	GetMapFeatures(bbox *model.BoundingBox, feature string) ([]model.MapFeature, error)
+	SearchBuildingFeatures(text string) ([]model.BuildingFeatureSearchResult, error)
+	GetWalkingRoute(fromLatitude float64, fromLongitude float64, toLatitude float64, toLongitude float64, bldgID string, accessibleOnly bool) (*model.WalkingRoute, error)
 	GetCrowdMeterDataForLocation(locationid int, crowdtype string) (*model.Crowd, error)
 	GetCrowdMeterData() (*[]model.Crowd, error)
 	GetCrowdMeterDataByType(crowdtype string) (*[]model.Crowd, error)
+	GetImage(key string) ([]byte, string, error)
+	SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error)
+	GetLegacyEventsFeed(query model.LegacyEventsQuery) ([]model.LegacyEvent, error)
+	GetLegacyEventsNear(latitude float64, longitude float64, radius float64, query model.LegacyEventsQuery) ([]model.NearbyLegacyEvent, error)
 }
 
 // Admin exposes administrative APIs for the driver adapters
@@ -65,11 +77,28 @@ type Admin interface {
 	CreateConfig(config model.Config, claims *tokenauth.Claims) (*model.Config, error)
 	UpdateConfig(config model.Config, claims *tokenauth.Claims) error
 	DeleteConfig(id string, claims *tokenauth.Claims) error
-	AddWebtoolsBlackList(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error
-	GetWebtoolsBlackList() ([]model.Blacklist, error)
-	RemoveWebtoolsBlackList(sourceids []string, calendarids []string, originatingCalendarIdsList []string) error
-	GetEventsSummary() (*model.EventsSummary, error)
-	GetEventsItems(source *string, status *string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error)
+	AddWebtoolsBlackList(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error
+	GetWebtoolsBlackList(orgID string, appID string) ([]model.Blacklist, error)
+	RemoveWebtoolsBlackList(orgID string, appID string, sourceids []string, calendarids []string, originatingCalendarIdsList []string) error
+	GetEventsSummary(orgID string, appID string) (*model.EventsSummary, error)
+	GetEventsItems(orgID string, appID string, source *string, status *string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error)
+	SearchArchivedEvents(query model.LegacyEventsQuery) ([]model.ArchivedLegacyEventItem, error)
+	GetEventHistory(orgID string, appID string, id string) ([]model.LegacyEventHistoryItem, error)
+	GetWebToolsSyncReports(orgID string, appID string, limit int64) ([]model.WebToolsSyncReport, error)
+	GetEventsModerationQueue(orgID string, appID string) ([]model.LegacyEventItem, error)
+	ModerateEvent(orgID string, appID string, id string, approved bool, reason string, moderatorID string) (*model.LegacyEventItem, error)
+	ReconcileEventsBB(orgID string, appID string, backfillGateway bool, backfillEventsBB bool) (*model.LegacyEventsReconciliation, error)
+	GetBuildingAliases() ([]model.BuildingAlias, error)
+	CreateBuildingAlias(alias string, buildingID string) (*model.BuildingAlias, error)
+	DeleteBuildingAlias(id string) error
+	GetBuildingFeatureCatalog() ([]model.AppBuildingFeature, error)
+	CreateBuildingFeatureCatalogEntry(item model.AppBuildingFeature) (*model.AppBuildingFeature, error)
+	UpdateBuildingFeatureCatalogEntry(item model.AppBuildingFeature) error
+	DeleteBuildingFeatureCatalogEntry(campusCode string) error
+	GetFeatureLocations(buildingNumber *string) ([]model.ManagedFeatureLocation, error)
+	CreateFeatureLocation(buildingNumber string, key string, name string, floors []string) (*model.ManagedFeatureLocation, error)
+	UpdateFeatureLocation(id string, name string, floors []string) error
+	DeleteFeatureLocation(id string) error
 }
 
 // BBs exposes Building Block APIs for the driver adapters
@@ -81,14 +110,18 @@ type BBs interface {
 	CreateAppointment(appt *model.AppointmentPost, accessToken string) (*model.BuildingBlockAppointment, error)
 	DeleteAppointment(uin string, providerid int, sourceid string, accesstoken string) (string, error)
 	UpdateAppointment(appt *model.AppointmentPost, accessToken string) (*model.BuildingBlockAppointment, error)
-	GetLegacyEvents() ([]model.LegacyEvent, error)
+	GetLegacyEvents(audiences []string, attendanceModes []string) ([]model.LegacyEvent, error)
+	SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error)
 }
 
 // TPS exposes third-party service APIs for the driver adapters
 type TPS interface {
 	GetExample(orgID string, appID string, id string) (*model.Example, error)
 	CreateEvents(event []model.LegacyEventItem) ([]model.LegacyEventItem, error)
-	DeleteEvents(ids []string, accountID string) error
+	DeleteEvents(orgID string, appID string, ids []string, accountID string) error
+	GetEvents(query model.LegacyEventsQuery) ([]model.LegacyEventItem, error)
+	GetEvent(orgID string, appID string, id string, accountID string) (*model.LegacyEventItem, error)
+	GetEventsModeration(orgID string, appID string, accountID string) ([]model.TPSEventModeration, error)
 }
 
 // System exposes system administrative APIs for the driver adapters
@@ -101,11 +134,33 @@ type Shared interface {
 	getExample(orgID string, appID string, id string) (*model.Example, error)
 	getBuildingFeatures() ([]model.AppBuildingFeature, error)
 	getFloorPlanMarkup() (*model.FloorPlanMarkup, error)
+	searchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error)
 }
 
 // EventsBBAdapter is used by core to communicate with the events BB
 type EventsBBAdapter interface {
 	LoadAllLegacyEvents() ([]model.LegacyEvent, error)
+	CreateLegacyEvent(event model.LegacyEvent) error
+}
+
+// WebToolsFeed is used by core to load the web tools events feed pages
+type WebToolsFeed interface {
+	LoadPage(page int) ([]byte, error)
+}
+
+// SidearmFeed is used by core to load the Sidearm athletics schedule feed
+type SidearmFeed interface {
+	LoadGames(feedURL string) ([]model.SidearmGame, error)
+}
+
+// WalkingPaths is used by core to load the campus pedestrian paths
+type WalkingPaths interface {
+	LoadPaths() ([]model.WalkingPath, error)
+}
+
+// BuildingFootprints is used by core to load the outlines of the buildings
+type BuildingFootprints interface {
+	LoadFootprints() ([]model.BuildingFootprint, error)
 }
 
 // GeoAdapter is used by core to get geo services
@@ -116,6 +171,7 @@ type GeoAdapter interface {
 // ImageAdapter  is used to precess images
 type ImageAdapter interface {
 	ProcessImage(item model.WebToolsEvent) (*model.ContentImagesURL, error)
+	LoadImage(key string) ([]byte, string, error)
 }
 
 // Storage is used by core to storage data - DB storage adapter, file storage adapter etc
@@ -143,33 +199,68 @@ type Storage interface {
 	InitializeLegacyLocations() error
 	FindLegacyLocations() (model.LegacyLocationsListType, error)
 
-	FindLegacyEventItems(context storage.TransactionContext, source *string, statuses *[]string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error)
-	FindLegacyEventItemsBySourceID(context storage.TransactionContext, sourceID string) ([]model.LegacyEventItem, error)
+	FindLegacyEventItems(context storage.TransactionContext, orgID string, appID string, source *string, statuses *[]string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error)
+	FindLegacyEventItemsBySourceID(context storage.TransactionContext, orgID string, appID string, sourceID string) ([]model.LegacyEventItem, error)
+	FindExistingLegacyEventIDs(ids []string) (map[string]bool, error)
 	InsertLegacyEvents(context storage.TransactionContext, items []model.LegacyEventItem) ([]model.LegacyEventItem, error)
 	DeleteLegacyEventsByIDs(context storage.TransactionContext, Ids map[string]string) error
-	DeleteLegacyEventsBySourceID(context storage.TransactionContext, sourceID string) error
-	DeleteLegacyEventsByIDsAndCreator(context storage.TransactionContext, ids []string, accountID string) error
-	FindLegacyEvents(source *string, status *string) ([]model.LegacyEvent, error)
-
-	FindWebtoolsBlacklistData(context storage.TransactionContext) ([]model.Blacklist, error)
-	AddWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error
-	RemoveWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIdsList []string) error
-	FindWebtoolsOriginatingCalendarIDsBlacklistData() ([]model.Blacklist, error)
+	DeleteLegacyEventsBySourceID(context storage.TransactionContext, orgID string, appID string, sourceID string) error
+	FindLegacyEventItemsByIDsAndCreator(context storage.TransactionContext, orgID string, appID string, ids []string, accountID string) ([]model.LegacyEventItem, error)
+	FindPendingLegacyEventItems(context storage.TransactionContext, orgID string, appID string, id *string) ([]model.LegacyEventItem, error)
+	UpdateLegacyEventModeration(context storage.TransactionContext, item model.LegacyEventItem) error
+	DeleteLegacyEventsByIDsAndCreator(context storage.TransactionContext, orgID string, appID string, ids []string, accountID string) error
+	FindLegacyEvents(orgID string, appID string, source *string, status *string) ([]model.LegacyEvent, error)
+	SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error)
+	SearchLegacyEventItems(query model.LegacyEventsQuery) ([]model.LegacyEventItem, error)
+	FindLegacyEventsNear(latitude float64, longitude float64, radius float64, query model.LegacyEventsQuery) ([]model.NearbyLegacyEvent, error)
+	SetMissingLegacyEventsTenant(orgID string, appID string) (int64, error)
+	FindLegacyEventItemsWithoutQueryFields(context storage.TransactionContext) ([]model.LegacyEventItem, error)
+	UpdateLegacyEventItemQueryFields(context storage.TransactionContext, item model.LegacyEventItem) error
+	ArchiveLegacyEvents(context storage.TransactionContext, endedBefore time.Time, purgeAfter time.Duration) (int, error)
+	SearchArchivedLegacyEvents(query model.LegacyEventsQuery) ([]model.ArchivedLegacyEventItem, error)
+	InsertLegacyEventsHistory(context storage.TransactionContext, items []model.LegacyEventHistoryItem) error
+	FindLegacyEventHistory(orgID string, appID string, eventID string) ([]model.LegacyEventHistoryItem, error)
+	InsertWebToolsSyncReport(report model.WebToolsSyncReport) error
+	FindWebToolsSyncReports(orgID string, appID string, limit int64) ([]model.WebToolsSyncReport, error)
+
+	FindWebtoolsBlacklistData(context storage.TransactionContext, orgID string, appID string) ([]model.Blacklist, error)
+	AddWebtoolsBlacklistData(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error
+	RemoveWebtoolsBlacklistData(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIdsList []string) error
+	FindWebtoolsOriginatingCalendarIDsBlacklistData(orgID string, appID string) ([]model.Blacklist, error)
 
 	FindImageItems() ([]model.ContentImagesURL, error)
-	InsertImageItem(items model.ContentImagesURL) error
+	SaveImageItem(item model.ContentImagesURL) error
 
 	FindLegacyLocationItems() ([]model.LegacyLocation, error)
 	InsertLegacyLocationItem(items model.LegacyLocation) error
 
 	LoadAppBuildingFeatures() ([]model.AppBuildingFeature, error)
+	InsertAppBuildingFeature(feature model.AppBuildingFeature) error
+	UpdateAppBuildingFeature(feature model.AppBuildingFeature) error
+	DeleteAppBuildingFeature(campusCode string) error
+	FindManagedFeatureLocations(buildingNumber *string) ([]model.ManagedFeatureLocation, error)
+	InsertManagedFeatureLocation(location model.ManagedFeatureLocation) error
+	UpdateManagedFeatureLocation(location model.ManagedFeatureLocation) error
+	DeleteManagedFeatureLocation(id string) error
 	LoadFloorPlanMarkup() (*model.FloorPlanMarkup, error)
+
+	FindLatestBuildingsDataset(context storage.TransactionContext) (*model.BuildingsDataset, error)
+	InsertBuildingsDataset(context storage.TransactionContext, dataset model.BuildingsDataset) error
+	UpdateBuildingsDatasetDateChecked(context storage.TransactionContext, id string, dateChecked time.Time) error
+	DeleteBuildingsDatasetsBefore(context storage.TransactionContext, version int) error
+
+	FindBuildingAliases() ([]model.BuildingAlias, error)
+	InsertBuildingAlias(alias model.BuildingAlias) error
+	DeleteBuildingAlias(id string) error
 }
 
 // StorageListener represents storage listener
 type StorageListener interface {
 	OnConfigsUpdated()
 	OnExamplesUpdated()
+	OnBuildingFeaturesUpdated()
+	OnManagedFeatureLocationsUpdated()
+	OnBuildingAliasesUpdated()
 }
 
 // Contact represents the adapter needed to pull campus specific contact information
@@ -194,7 +285,7 @@ type LaundryService interface {
 
 // WayFinding represents the adapter needed to interact with vendor specific building locations
 type WayFinding interface {
-	GetEntrance(bldgID string, adaAccessibleOnly bool, latitude float64, longitude float64, conf *model.EnvConfigData) (*model.Entrance, error)
+	GetEntrance(bldgID string, adaAccessibleOnly bool, latitude float64, longitude float64, primaryEntranceID string, conf *model.EnvConfigData) (*model.NearestEntrance, error)
 	GetBuildings(conf *model.EnvConfigData) (*[]model.Building, error)
 	GetBuilding(bldgID string, adaAccessibleOnly bool, latitude float64, longitude float64, conf *model.EnvConfigData) (*model.Building, error)
 	GetFloorPlan(bldgNum string, floornumber string, markers string, highlites string, markup string, conf *model.EnvConfigData) (*model.FloorPlan, error)
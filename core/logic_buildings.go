@@ -0,0 +1,353 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/driven/storage"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//buildingsRefreshPeriod is how often the buildings are loaded from the building adapter
+	buildingsRefreshPeriod = time.Hour * 24
+	//buildingsDatasetsKept is the number of the buildings dataset versions kept in the storage
+	buildingsDatasetsKept = 10
+)
+
+type buildingsLogic struct {
+	app    *Application
+	logger logs.Logger
+
+	wayFinding         WayFinding
+	buildingFootprints BuildingFootprints
+
+	//guards the cached dataset in the application, what it is made of and its search index with the aliases
+	datasetLock *sync.RWMutex
+	source      *buildingsCacheSource
+	searchIndex *buildingsSearchIndex
+
+	//the footprints by building number, loaded on start and read only after it
+	footprints map[string]model.BuildingFootprint
+
+	//serializes the refreshes by the timer and by the requests, they would store the same dataset version
+	refreshLock *sync.Mutex
+
+	//refresh timer
+	timerDone chan bool
+}
+
+// buildingsCacheSource holds what the cached dataset is made of
+type buildingsCacheSource struct {
+	storedDataset    *model.BuildingsDataset //as stored, without the managed feature locations
+	featureLocations []model.ManagedFeatureLocation
+	aliases          []model.BuildingAlias //searched with the buildings
+}
+
+func (b buildingsLogic) start() error {
+	//1. the managed feature locations are applied to the cached dataset and the aliases are indexed with it
+	b.loadFeatureLocations()
+	b.loadAliases()
+
+	//2. serve the stored dataset until it gets refreshed, the buildings are available right after a restart
+	dataset, err := b.app.storage.FindLatestBuildingsDataset(nil)
+	if err != nil {
+		b.logger.Errorf("error on loading the buildings dataset - %s", err)
+	} else if dataset != nil {
+		b.setDataset(dataset)
+		b.logger.Infof("loaded buildings dataset version %d with %d buildings", dataset.Version, len(dataset.Buildings))
+	}
+
+	//3. load the footprints drawn on the maps, the buildings are points without them
+	if b.buildingFootprints != nil {
+		footprints, err := b.buildingFootprints.LoadFootprints()
+		if err != nil {
+			//the footprints file is configured on purpose, a broken one must not leave the maps silently without them
+			return errors.WrapErrorAction(logutils.ActionLoad, model.TypeBuildingFootprint, nil, err)
+		}
+		for _, footprint := range footprints {
+			b.footprints[footprint.BuildingNumber] = footprint
+		}
+		b.logger.Infof("loaded %d building footprints", len(footprints))
+	}
+
+	//4. set up the refresh timer
+	go b.setupRefreshTimer(dataset)
+
+	return nil
+}
+
+func (b buildingsLogic) setupRefreshTimer(dataset *model.BuildingsDataset) {
+	//refresh right away if the stored dataset is missing or outdated
+	duration := time.Duration(0)
+	if dataset != nil {
+		duration = time.Until(dataset.DateChecked.Add(buildingsRefreshPeriod))
+		if duration < 0 {
+			duration = 0
+		}
+	}
+	b.logger.Infof("setupRefreshTimer -> first buildings refresh after %s", duration)
+
+	for {
+		timer := time.NewTimer(duration)
+		select {
+		case <-timer.C:
+			b.refresh()
+		case <-b.timerDone:
+			// timer aborted
+			b.logger.Info("setupRefreshTimer -> buildings timer aborted")
+			timer.Stop()
+			return
+		}
+		duration = buildingsRefreshPeriod
+	}
+}
+
+// refresh loads the buildings from the building adapter and stores them as a new dataset version if they have changed
+func (b buildingsLogic) refresh() (*model.BuildingsDataset, error) {
+	b.refreshLock.Lock()
+	defer b.refreshLock.Unlock()
+
+	conf, _ := b.app.GetEnvConfigs()
+	buildings, err := b.wayFinding.GetBuildings(conf)
+	if err != nil {
+		b.logger.Errorf("error on loading the buildings - %s", err)
+		return nil, err
+	}
+	if buildings == nil || len(*buildings) == 0 {
+		//do not replace the dataset because of an incomplete response
+		err = errors.ErrorData(logutils.StatusMissing, model.TypeBuilding, nil)
+		b.logger.Warnf("no buildings loaded, the buildings dataset is not refreshed")
+		return nil, err
+	}
+
+	//the building adapter gives the features in random order, they must not be seen as changes
+	for i := range *buildings {
+		features := (*buildings)[i].Features
+		sort.SliceStable(features, func(a, b int) bool { return features[a].Key < features[b].Key })
+	}
+
+	now := time.Now()
+	var current *model.BuildingsDataset
+	created := false
+	err = b.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		current = nil
+		created = false
+		latest, err := b.app.storage.FindLatestBuildingsDataset(context)
+		if err != nil {
+			return err
+		}
+
+		//all the buildings are added by the first version
+		var previous []model.Building
+		version := 1
+		if latest != nil {
+			previous = latest.Buildings
+			version = latest.Version + 1
+		}
+		changes := diffBuildings(previous, *buildings)
+		if latest != nil && changes.IsEmpty() {
+			latest.DateChecked = now
+			current = latest
+			return b.app.storage.UpdateBuildingsDatasetDateChecked(context, latest.ID, now)
+		}
+
+		dataset := model.BuildingsDataset{ID: uuid.NewString(), Version: version, Buildings: *buildings, Changes: changes,
+			DateCreated: now, DateChecked: now}
+		err = b.app.storage.InsertBuildingsDataset(context, dataset)
+		if err != nil {
+			return err
+		}
+		err = b.app.storage.DeleteBuildingsDatasetsBefore(context, version-buildingsDatasetsKept+1)
+		if err != nil {
+			return err
+		}
+		current = &dataset
+		created = true
+		return nil
+	}, 60000)
+	if err != nil {
+		b.logger.Errorf("error on storing the buildings dataset - %s", err)
+		return nil, err
+	}
+
+	if created {
+		b.logger.Infof("buildings dataset version %d - %d added, %d removed, %d renamed, %d with changed entrances, %d updated",
+			current.Version, len(current.Changes.Added), len(current.Changes.Removed), len(current.Changes.Renamed),
+			len(current.Changes.EntrancesChanged), len(current.Changes.Updated))
+	}
+	b.setDataset(current)
+	return b.getDataset(), nil
+}
+
+// getDataset gives the cached buildings dataset, nil if it has not been loaded yet
+func (b buildingsLogic) getDataset() *model.BuildingsDataset {
+	b.datasetLock.RLock()
+	defer b.datasetLock.RUnlock()
+
+	return b.app.CampusBuildings
+}
+
+func (b buildingsLogic) setDataset(dataset *model.BuildingsDataset) {
+	b.datasetLock.Lock()
+	defer b.datasetLock.Unlock()
+
+	b.source.storedDataset = dataset
+	b.updateCachedDataset()
+}
+
+// loadFeatureLocations loads the managed feature locations and applies them to the cached dataset
+func (b buildingsLogic) loadFeatureLocations() {
+	locations, err := b.app.storage.FindManagedFeatureLocations(nil)
+	if err != nil {
+		b.logger.Errorf("error on loading the managed feature locations - %s", err)
+		return
+	}
+
+	b.datasetLock.Lock()
+	defer b.datasetLock.Unlock()
+
+	b.source.featureLocations = locations
+	if b.source.storedDataset != nil {
+		b.updateCachedDataset()
+	}
+}
+
+// loadAliases loads the building aliases and indexes them with the cached dataset
+func (b buildingsLogic) loadAliases() {
+	aliases, err := b.app.storage.FindBuildingAliases()
+	if err != nil {
+		b.logger.Errorf("error on loading the building aliases - %s", err)
+		return
+	}
+
+	b.datasetLock.Lock()
+	defer b.datasetLock.Unlock()
+
+	b.source.aliases = aliases
+	if b.app.CampusBuildings != nil {
+		*b.searchIndex = newBuildingsSearchIndex(b.app.CampusBuildings, aliases)
+	}
+}
+
+// getAliases gives the cached building aliases
+func (b buildingsLogic) getAliases() []model.BuildingAlias {
+	b.datasetLock.RLock()
+	defer b.datasetLock.RUnlock()
+
+	return b.source.aliases
+}
+
+// updateCachedDataset sets the cached dataset from the stored one and the managed feature locations, the caller holds the lock
+func (b buildingsLogic) updateCachedDataset() {
+	dataset := withFeatureLocations(b.source.storedDataset, b.source.featureLocations)
+	b.app.CampusBuildings = dataset
+	*b.searchIndex = newBuildingsSearchIndex(dataset, b.source.aliases)
+}
+
+// onBuildingFeaturesUpdated reloads the building features catalog. The buildings get their features by the catalog
+// when they are loaded from the building adapter, so they are refreshed
+func (b buildingsLogic) onBuildingFeaturesUpdated() {
+	features, err := b.app.storage.LoadAppBuildingFeatures()
+	if err != nil {
+		b.logger.Errorf("error on loading the building features catalog - %s", err)
+		return
+	}
+	b.app.setAppBuildingFeatures(features)
+	b.logger.Infof("loaded %d building features catalog entries", len(features))
+
+	_, err = b.refresh()
+	if err != nil {
+		b.logger.Errorf("error on refreshing the buildings for the building features catalog - %s", err)
+	}
+}
+
+// searchBuildings ranks the buildings of the cached dataset by their relevance for the text
+func (b buildingsLogic) searchBuildings(text string, limit int) ([]model.BuildingSearchResult, error) {
+	b.datasetLock.RLock()
+	index := *b.searchIndex
+	b.datasetLock.RUnlock()
+
+	return index.search(text, limit), nil
+}
+
+// diffBuildings gives the changes from the previous to the current buildings
+func diffBuildings(previous []model.Building, current []model.Building) model.BuildingsDatasetChanges {
+	changes := model.BuildingsDatasetChanges{Added: []string{}, Removed: []string{}, Renamed: []model.BuildingRename{},
+		EntrancesChanged: []string{}, Updated: []string{}}
+
+	previousBuildings := make(map[string]model.Building, len(previous))
+	for _, building := range previous {
+		previousBuildings[buildingKey(building)] = building
+	}
+
+	currentKeys := make(map[string]bool, len(current))
+	for _, building := range current {
+		key := buildingKey(building)
+		currentKeys[key] = true
+
+		previousBuilding, found := previousBuildings[key]
+		if !found {
+			changes.Added = append(changes.Added, key)
+			continue
+		}
+		if reflect.DeepEqual(previousBuilding, building) {
+			continue
+		}
+
+		if previousBuilding.Name != building.Name {
+			changes.Renamed = append(changes.Renamed, model.BuildingRename{ID: key, OldName: previousBuilding.Name, NewName: building.Name})
+		}
+		if !reflect.DeepEqual(previousBuilding.Entrances, building.Entrances) {
+			changes.EntrancesChanged = append(changes.EntrancesChanged, key)
+		}
+		//any other modified details
+		previousBuilding.Name, previousBuilding.Entrances = building.Name, building.Entrances
+		if !reflect.DeepEqual(previousBuilding, building) {
+			changes.Updated = append(changes.Updated, key)
+		}
+	}
+
+	for _, building := range previous {
+		key := buildingKey(building)
+		if !currentKeys[key] {
+			changes.Removed = append(changes.Removed, key)
+		}
+	}
+	return changes
+}
+
+// buildingKey identifies the building across the dataset versions
+func buildingKey(building model.Building) string {
+	if len(building.ID) > 0 {
+		return building.ID
+	}
+	return building.Number
+}
+
+// newBuildingsLogic creates new buildingsLogic
+func newBuildingsLogic(app *Application, wayFinding WayFinding, buildingFootprints BuildingFootprints, logger logs.Logger) buildingsLogic {
+	timerDone := make(chan bool)
+	return buildingsLogic{app: app, wayFinding: wayFinding, buildingFootprints: buildingFootprints, datasetLock: &sync.RWMutex{}, source: &buildingsCacheSource{}, searchIndex: &buildingsSearchIndex{},
+		footprints: map[string]model.BuildingFootprint{}, refreshLock: &sync.Mutex{}, timerDone: timerDone, logger: logger}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"sort"
+	"strings"
+)
+
+// withFeatureLocations gives a copy of the dataset with the managed feature locations applied to its buildings.
+// A managed location replaces the feature with the same key, adds it when it is missing and removes it when it has no floors
+func withFeatureLocations(dataset *model.BuildingsDataset, locations []model.ManagedFeatureLocation) *model.BuildingsDataset {
+	if dataset == nil || len(locations) == 0 {
+		return dataset
+	}
+
+	locationsByBuilding := map[string][]model.ManagedFeatureLocation{}
+	for _, location := range locations {
+		locationsByBuilding[location.BuildingNumber] = append(locationsByBuilding[location.BuildingNumber], location)
+	}
+
+	result := *dataset
+	result.Buildings = make([]model.Building, len(dataset.Buildings))
+	for i, building := range dataset.Buildings {
+		buildingLocations, ok := locationsByBuilding[building.Number]
+		if ok {
+			building.Features = applyFeatureLocations(building.Features, buildingLocations)
+		}
+		result.Buildings[i] = building
+	}
+	return &result
+}
+
+// applyFeatureLocations gives the features of a building with its managed feature locations applied, sorted by key
+func applyFeatureLocations(features []model.BuildingFeatureLocation, locations []model.ManagedFeatureLocation) []model.BuildingFeatureLocation {
+	managed := make(map[string]model.ManagedFeatureLocation, len(locations))
+	for _, location := range locations {
+		managed[location.Key] = location
+	}
+
+	result := make([]model.BuildingFeatureLocation, 0, len(features)+len(locations))
+	for _, feature := range features {
+		if _, ok := managed[feature.Key]; !ok {
+			result = append(result, feature)
+		}
+	}
+	for _, location := range managed {
+		if len(location.Floors) == 0 {
+			continue
+		}
+		result = append(result, model.BuildingFeatureLocation{Key: location.Key,
+			Value: model.FeatureMapEntry{Name: location.Name, Floors: location.Floors}})
+	}
+	sort.SliceStable(result, func(a, b int) bool { return result[a].Key < result[b].Key })
+	return result
+}
+
+// searchBuildingFeatures gives the buildings which have the features matching all the words of the text.
+// The features are matched by their key and name and by the campus name and code of their catalog entries
+func searchBuildingFeatures(buildings []model.Building, catalog map[string]model.AppBuildingFeature, text string) []model.BuildingFeatureSearchResult {
+	result := []model.BuildingFeatureSearchResult{}
+	queryWords := searchWords(text)
+	if len(queryWords) == 0 {
+		return result
+	}
+
+	//the catalog entries by app code, which is the key of the building features
+	catalogWords := map[string][]string{}
+	for _, entry := range catalog {
+		catalogWords[entry.AppCode] = append(catalogWords[entry.AppCode], searchWords(entry.CampusName+" "+entry.CampusCode)...)
+	}
+
+	for _, building := range buildings {
+		for _, feature := range building.Features {
+			words := searchWords(feature.Key + " " + feature.Value.Name)
+			words = append(words, catalogWords[feature.Key]...)
+			if matchesFeatureWords(queryWords, words) {
+				result = append(result, model.BuildingFeatureSearchResult{Building: building, Feature: feature})
+			}
+		}
+	}
+	sort.SliceStable(result, func(a, b int) bool { return result[a].Building.Name < result[b].Building.Name })
+	return result
+}
+
+// matchesFeatureWords checks that every query word starts one of the feature words. The longer query words also match
+// the feature words they start with - "elevators" matches "elevator"
+func matchesFeatureWords(queryWords []string, featureWords []string) bool {
+	for _, queryWord := range queryWords {
+		matched := false
+		for _, featureWord := range featureWords {
+			if strings.HasPrefix(featureWord, queryWord) || (len(featureWord) >= 4 && strings.HasPrefix(queryWord, featureWord)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"sort"
+)
+
+// mapBuildings gives the buildings in the box, drawn by their footprints when they are known.
+// The buildings without footprints and coordinates can not be drawn and are skipped
+func mapBuildings(buildings []model.Building, footprints map[string]model.BuildingFootprint, bbox *model.BoundingBox) []model.MapBuilding {
+	result := []model.MapBuilding{}
+	for _, building := range buildings {
+		item := model.MapBuilding{Building: building}
+		if footprint, ok := footprints[building.Number]; ok && len(footprint.Polygons) > 0 {
+			item.Footprint = &footprint
+			if bbox != nil && !bbox.Intersects(footprint.BoundingBox()) {
+				continue
+			}
+		} else if (building.Latitude == 0 && building.Longitude == 0) || (bbox != nil && !bbox.Contains(building.Latitude, building.Longitude)) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// mapEntrances gives the available entrances in the box
+func mapEntrances(buildings []model.Building, bbox *model.BoundingBox, adaOnly bool) []model.MapEntrance {
+	result := []model.MapEntrance{}
+	for _, building := range buildings {
+		for _, entrance := range building.Entrances {
+			if !entrance.Available || (adaOnly && !entrance.ADACompliant) || (entrance.Latitude == 0 && entrance.Longitude == 0) {
+				continue
+			}
+			if bbox != nil && !bbox.Contains(entrance.Latitude, entrance.Longitude) {
+				continue
+			}
+			result = append(result, model.MapEntrance{Entrance: entrance, BuildingID: building.ID, BuildingNumber: building.Number, BuildingName: building.Name})
+		}
+	}
+	return result
+}
+
+// mapFeatures gives the features of the buildings in the box, all of them or the ones with the feature key.
+// The features are joined with the catalog entries by the app code
+func mapFeatures(buildings []model.Building, catalog map[string]model.AppBuildingFeature, bbox *model.BoundingBox, feature string) []model.MapFeature {
+	campusCodes := make([]string, 0, len(catalog))
+	for campusCode := range catalog {
+		campusCodes = append(campusCodes, campusCode)
+	}
+	sort.Strings(campusCodes)
+	catalogCodes := map[string]string{}
+	for _, campusCode := range campusCodes {
+		appCode := catalog[campusCode].AppCode
+		if _, ok := catalogCodes[appCode]; !ok {
+			catalogCodes[appCode] = campusCode
+		}
+	}
+
+	result := []model.MapFeature{}
+	for _, building := range buildings {
+		if building.Latitude == 0 && building.Longitude == 0 {
+			continue
+		}
+		if bbox != nil && !bbox.Contains(building.Latitude, building.Longitude) {
+			continue
+		}
+		for _, location := range building.Features {
+			if len(feature) > 0 && location.Key != feature {
+				continue
+			}
+			result = append(result, model.MapFeature{Key: location.Key, Name: location.Value.Name, CampusCode: catalogCodes[location.Key], Floors: location.Value.Floors,
+				BuildingID: building.ID, BuildingNumber: building.Number, BuildingName: building.Name, Latitude: building.Latitude, Longitude: building.Longitude})
+		}
+	}
+	return result
+}
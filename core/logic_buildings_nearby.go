@@ -0,0 +1,62 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/utils"
+	"slices"
+	"sort"
+)
+
+// findNearbyBuildings gives the buildings within the radius of the point, the closest come first.
+// The distance is to the nearest available entrance, or to the building center when the building has no entrances.
+func findNearbyBuildings(buildings []model.Building, latitude float64, longitude float64, radius float64, feature string, adaOnly bool, limit int) []model.NearbyBuilding {
+	result := []model.NearbyBuilding{}
+	for _, building := range buildings {
+		if len(feature) > 0 && !slices.ContainsFunc(building.Features, func(f model.BuildingFeatureLocation) bool { return f.Key == feature }) {
+			continue
+		}
+
+		nearby := model.NearbyBuilding{Building: building, Distance: -1}
+		for i, entrance := range building.Entrances {
+			if !entrance.Available || (adaOnly && !entrance.ADACompliant) {
+				continue
+			}
+			distance := utils.GreatCircleDistance(latitude, longitude, entrance.Latitude, entrance.Longitude)
+			if nearby.Distance < 0 || distance < nearby.Distance {
+				nearby.Distance = distance
+				nearby.NearestEntrance = &building.Entrances[i]
+			}
+		}
+		if nearby.NearestEntrance == nil {
+			//the building can not be entered by an accessible entrance
+			if adaOnly || (building.Latitude == 0 && building.Longitude == 0) {
+				continue
+			}
+			nearby.Distance = utils.GreatCircleDistance(latitude, longitude, building.Latitude, building.Longitude)
+		}
+
+		if nearby.Distance <= radius {
+			result = append(result, nearby)
+		}
+	}
+
+	sort.SliceStable(result, func(a, b int) bool { return result[a].Distance < result[b].Distance })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	//buildingsSearchMinScore is the lowest relevance of the found buildings
+	buildingsSearchMinScore = 0.5
+	//buildingsSearchDefaultLimit is the default number of the found buildings
+	buildingsSearchDefaultLimit = 20
+)
+
+// buildingsSearchStopWords are ignored by the buildings search - "the Union" is "Union"
+var buildingsSearchStopWords = []string{"the", "of", "and", "for", "at", "a"}
+
+// buildingsSearchIndex holds the normalized searchable fields and aliases of the buildings of a dataset version
+type buildingsSearchIndex struct {
+	version int
+	entries []buildingSearchEntry
+}
+
+type buildingSearchEntry struct {
+	building model.Building
+	fields   []buildingSearchField
+}
+
+// buildingSearchField is a normalized building field with its weight in the relevance
+type buildingSearchField struct {
+	name   string
+	weight float64
+	text   string
+	words  []string
+}
+
+// newBuildingsSearchIndex indexes the name, short name, number, address and aliases of the dataset buildings.
+// The aliases are matched like the building fields.
+func newBuildingsSearchIndex(dataset *model.BuildingsDataset, aliases []model.BuildingAlias) buildingsSearchIndex {
+	aliasFields := make(map[string][]buildingSearchField)
+	for _, alias := range aliases {
+		aliasFields[alias.BuildingID] = append(aliasFields[alias.BuildingID], newBuildingSearchField("alias", 1, alias.Alias))
+	}
+
+	index := buildingsSearchIndex{version: dataset.Version, entries: make([]buildingSearchEntry, len(dataset.Buildings))}
+	for i, building := range dataset.Buildings {
+		fields := []buildingSearchField{
+			newBuildingSearchField("name", 1, building.Name),
+			newBuildingSearchField("short_name", 0.95, building.ShortName),
+			newBuildingSearchField("number", 1, building.Number),
+			newBuildingSearchField("address", 0.7, building.FullAddress),
+		}
+		index.entries[i] = buildingSearchEntry{building: building, fields: append(fields, aliasFields[buildingKey(building)]...)}
+	}
+	return index
+}
+
+func newBuildingSearchField(name string, weight float64, value string) buildingSearchField {
+	words := searchWords(value)
+	return buildingSearchField{name: name, weight: weight, text: strings.Join(words, " "), words: words}
+}
+
+// search ranks the buildings by their relevance for the text. All the found buildings are given for limit 0.
+func (i buildingsSearchIndex) search(text string, limit int) []model.BuildingSearchResult {
+	words := searchWords(text)
+	if len(words) == 0 {
+		return []model.BuildingSearchResult{}
+	}
+	query := strings.Join(words, " ")
+
+	results := []model.BuildingSearchResult{}
+	for _, entry := range i.entries {
+		result := model.BuildingSearchResult{Building: entry.building}
+		for _, field := range entry.fields {
+			score := field.weight * scoreSearchField(query, words, field)
+			if score > result.Score {
+				result.Score = score
+				result.MatchedField = field.name
+			}
+		}
+		if result.Score >= buildingsSearchMinScore {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Building.Name < results[b].Building.Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// scoreSearchField gives the relevance of the field for the query from 0 to 1.
+// The whole query matches better than its separate words, which may be abbreviated or misspelled.
+func scoreSearchField(query string, words []string, field buildingSearchField) float64 {
+	if len(field.text) == 0 {
+		return 0
+	}
+	if field.text == query {
+		return 1
+	}
+	//the number matches only exactly
+	if field.name == "number" {
+		return 0
+	}
+	if strings.HasPrefix(field.text, query) {
+		return 0.9
+	}
+	if strings.Contains(" "+field.text, " "+query) {
+		return 0.85
+	}
+	if len(words) == 1 && len(query) >= 2 && query == searchInitials(field.words) {
+		return 0.85
+	}
+	if len(query) >= 3 && strings.Contains(field.text, query) {
+		return 0.75
+	}
+
+	total := 0.0
+	for _, word := range words {
+		best := 0.0
+		for _, fieldWord := range field.words {
+			best = max(best, scoreSearchWord(word, fieldWord))
+		}
+		total += best
+	}
+	return 0.8 * total / float64(len(words))
+}
+
+// scoreSearchWord gives the relevance of the field word for the query word, it tolerates one typo in 4 to 7 letters and two in longer words
+func scoreSearchWord(word string, fieldWord string) float64 {
+	if word == fieldWord {
+		return 1
+	}
+	if len(word) >= 2 && strings.HasPrefix(fieldWord, word) {
+		return 0.9
+	}
+
+	maxTypos := 0
+	if len(word) >= 8 {
+		maxTypos = 2
+	} else if len(word) >= 4 {
+		maxTypos = 1
+	}
+	if maxTypos == 0 {
+		return 0
+	}
+	typos := editDistance(word, fieldWord, maxTypos)
+	if typos > maxTypos {
+		return 0
+	}
+	return 0.95 - 0.15*float64(typos)
+}
+
+// searchWords normalizes the text into lower case words without punctuation and stop words
+func searchWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !slices.Contains(buildingsSearchStopWords, field) {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+// searchInitials gives the first letters of the words - "Digital Computer Laboratory" is "dcl"
+func searchInitials(words []string) string {
+	var initials strings.Builder
+	for _, word := range words {
+		initials.WriteRune([]rune(word)[0])
+	}
+	return initials.String()
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of adjacent letters between the words,
+// maxDistance + 1 when the distance is larger than maxDistance
+func editDistance(a string, b string, maxDistance int) int {
+	s, t := []rune(a), []rune(b)
+	if abs(len(s)-len(t)) > maxDistance {
+		return maxDistance + 1
+	}
+
+	rows := make([][]int, len(s)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return min(rows[len(s)][len(t)], maxDistance+1)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
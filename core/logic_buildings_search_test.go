@@ -0,0 +1,153 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearchWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "The Illini Union", want: []string{"illini", "union"}},
+		{text: "  Roger Adams Lab. ", want: []string{"roger", "adams", "lab"}},
+		{text: "School of Music", want: []string{"school", "music"}},
+		{text: "the", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := searchWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchWords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           string
+		b           string
+		maxDistance int
+		want        int
+	}{
+		{name: "same", a: "union", b: "union", maxDistance: 1, want: 0},
+		{name: "substitution", a: "onion", b: "union", maxDistance: 1, want: 1},
+		{name: "transposition", a: "uinon", b: "union", maxDistance: 1, want: 1},
+		{name: "deletion", a: "enginering", b: "engineering", maxDistance: 2, want: 1},
+		{name: "two deletions", a: "engneerng", b: "engineering", maxDistance: 2, want: 2},
+		{name: "more than the max", a: "union", b: "lincoln", maxDistance: 1, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b, tt.maxDistance); got != tt.want {
+				t.Errorf("editDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSearchField(t *testing.T) {
+	name := func(value string) buildingSearchField { return newBuildingSearchField("name", 1, value) }
+
+	tests := []struct {
+		name  string
+		text  string
+		field buildingSearchField
+		want  float64
+	}{
+		{name: "exact", text: "illini union", field: name("Illini Union"), want: 1},
+		{name: "exact without the stop words", text: "the Illini Union", field: name("Illini Union"), want: 1},
+		{name: "prefix", text: "grainger", field: name("Grainger Engineering Library"), want: 0.9},
+		{name: "word prefix with a stop word", text: "the Union", field: name("Illini Union"), want: 0.85},
+		{name: "initials", text: "dcl", field: name("Digital Computer Laboratory"), want: 0.85},
+		{name: "substring", text: "ngineering", field: name("Grainger Engineering Library"), want: 0.75},
+		{name: "transposition typo", text: "grianger library", field: name("Grainger Engineering Library"), want: 0.8 * (0.8 + 1) / 2},
+		{name: "one typo", text: "enginering", field: name("Grainger Engineering Library"), want: 0.8 * 0.8},
+		{name: "two typos", text: "engneerng", field: name("Grainger Engineering Library"), want: 0.8 * 0.65},
+		{name: "typo in four letters", text: "hsll", field: name("Lincoln Hall"), want: 0.8 * 0.8},
+		{name: "no typo in three letters", text: "hxl", field: name("Lincoln Hall"), want: 0},
+		{name: "number exact", text: "0041", field: newBuildingSearchField("number", 1, "0041"), want: 1},
+		{name: "number prefix", text: "004", field: newBuildingSearchField("number", 1, "0041"), want: 0},
+		{name: "empty field", text: "union", field: name(""), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words := searchWords(tt.text)
+			got := scoreSearchField(strings.Join(words, " "), words, tt.field)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("scoreSearchField() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildingsSearchIndex(t *testing.T) {
+	dataset := model.BuildingsDataset{Version: 3, Buildings: []model.Building{
+		{ID: "1", Name: "Illini Union", Number: "0001"},
+		{ID: "2", Name: "Digital Computer Laboratory", ShortName: "DCL", Number: "0002"},
+		{ID: "3", Name: "Grainger Engineering Library", Number: "0041"},
+		{ID: "4", Name: "Lincoln Hall", Number: "0004"},
+		{ID: "5", Name: "Library", Number: "0005"},
+	}}
+	aliases := []model.BuildingAlias{{Alias: "grainger", BuildingID: "3"}, {Alias: "the quad union", BuildingID: "1"}}
+	index := newBuildingsSearchIndex(&dataset, aliases)
+
+	tests := []struct {
+		name        string
+		text        string
+		limit       int
+		want        []string
+		wantMatched string
+	}{
+		{name: "initials", text: "dcl", want: []string{"Digital Computer Laboratory"}, wantMatched: "short_name"},
+		{name: "stop words", text: "the Union", want: []string{"Illini Union"}, wantMatched: "name"},
+		{name: "alias", text: "quad union", want: []string{"Illini Union"}, wantMatched: "alias"},
+		{name: "number", text: "0041", want: []string{"Grainger Engineering Library"}, wantMatched: "number"},
+		{name: "exact match first", text: "library", want: []string{"Library", "Grainger Engineering Library"}, wantMatched: "name"},
+		{name: "limit", text: "library", limit: 1, want: []string{"Library"}, wantMatched: "name"},
+		{name: "typo", text: "lincon hall", want: []string{"Lincoln Hall"}, wantMatched: "name"},
+		{name: "two typos above the cutoff", text: "engneerng", want: []string{"Grainger Engineering Library"}, wantMatched: "name"},
+		{name: "below the cutoff", text: "engneerng xyzzy", want: []string{}},
+		{name: "short fragment", text: "ll", want: []string{}},
+		{name: "only stop words", text: "the", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := index.search(tt.text, tt.limit)
+			names := make([]string, len(results))
+			for i, result := range results {
+				names[i] = result.Building.Name
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Fatalf("search() = %q, want %q", names, tt.want)
+			}
+			if len(results) > 0 && results[0].MatchedField != tt.wantMatched {
+				t.Errorf("search() matched field = %s, want %s", results[0].MatchedField, tt.wantMatched)
+			}
+			for i := 1; i < len(results); i++ {
+				if results[i].Score > results[i-1].Score {
+					t.Errorf("search() scores are not ordered - %f before %f", results[i-1].Score, results[i].Score)
+				}
+			}
+		})
+	}
+}
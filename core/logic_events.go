@@ -20,14 +20,14 @@ package core
 import (
 	"application/core/model"
 	"application/driven/storage"
+	"application/utils"
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"slices"
@@ -52,12 +52,21 @@ var whitelistCategoryMap = map[string]string{
 	"sidearm":                  "Big 10 Athletics",
 }
 
+const (
+	//webToolsParallelPages is the number of the web tools feed pages loaded at the same time
+	webToolsParallelPages = 4
+	//webToolsMaxPages is the maximum number of the web tools feed pages loaded by a sync run
+	webToolsMaxPages = 200
+)
+
 type eventsLogic struct {
 	app    *Application
 	logger logs.Logger
 
 	eventsBBAdapter EventsBBAdapter
 	geoBBAdapter    GeoAdapter
+	webToolsFeed    WebToolsFeed
+	sidearmFeed     SidearmFeed
 
 	//web tools timer
 	dailyWebToolsTimer *time.Timer
@@ -66,8 +75,23 @@ type eventsLogic struct {
 
 func (e eventsLogic) start() error {
 
-	//1. set up web tools timer
-	go e.setupWebToolsTimer()
+	//0. set the org and app of the events stored before the events were scoped by them, the sync must not miss these events
+	err := e.fillMissingTenant()
+	if err != nil {
+		return err
+	}
+
+	//1. prepare the stored events before the first sync, the archiving and the sync must not run at the same time
+	go func() {
+		//set the query fields for the events created before they were introduced, the ended events are found by them
+		e.fillMissingQueryFields()
+
+		//archive the events which have ended while the service was not running
+		e.archiveEndedEvents(nil)
+
+		//set up web tools timer
+		e.setupWebToolsTimer()
+	}()
 
 	//2. initialize event locations db if needs
 	go e.initializeDB()
@@ -75,6 +99,86 @@ func (e eventsLogic) start() error {
 	return nil
 }
 
+// archiveEndedEvents moves the events which have ended for the retention period into the archive
+func (e eventsLogic) archiveEndedEvents(context storage.TransactionContext) error {
+	endedBefore := time.Now().Add(-e.app.eventsRetention.ArchiveAfter)
+	count, err := e.app.storage.ArchiveLegacyEvents(context, endedBefore, e.app.eventsRetention.PurgeAfter)
+	if err != nil {
+		e.logger.Errorf("error on archiving the ended legacy events - %s", err)
+		return err
+	}
+	if count > 0 {
+		e.logger.Infof("archived %d legacy events ended before %s", count, endedBefore)
+	}
+	return nil
+}
+
+func (e eventsLogic) fillMissingTenant() error {
+	registration := e.app.webToolsRegistration
+	count, err := e.app.storage.SetMissingLegacyEventsTenant(registration.OrgID, registration.AppID)
+	if err != nil {
+		e.logger.Errorf("error on setting the org and app of the legacy events - %s", err)
+		return err
+	}
+	if count > 0 {
+		e.logger.Infof("set org %s and app %s to %d legacy events records", registration.OrgID, registration.AppID, count)
+	}
+	return nil
+}
+
+func (e eventsLogic) fillMissingQueryFields() {
+	items, err := e.app.storage.FindLegacyEventItemsWithoutQueryFields(nil)
+	if err != nil {
+		e.logger.Errorf("error on finding legacy events without query fields: %s", err)
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	e.logger.Infof("setting the query fields of %d legacy events", len(items))
+	for _, item := range items {
+		//the events stored before the descriptions were sanitized get their sanitized description and its derived forms
+		setLegacyEventDescription(&item.Item)
+		setLegacyEventQueryFields(&item)
+		err = e.app.storage.UpdateLegacyEventItemQueryFields(nil, item)
+		if err != nil {
+			e.logger.Errorf("error on setting the query fields of legacy event %s: %s", item.Item.ID, err)
+		}
+	}
+}
+
+// setLegacyEventQueryFields sets the fields used for querying from the event item data
+func setLegacyEventQueryFields(item *model.LegacyEventItem) {
+	if len(item.Item.AttendanceMode) == 0 {
+		item.Item.AttendanceMode = model.NewLegacyEventAttendanceMode(!item.Item.IsVirtial, item.Item.IsVirtial)
+	}
+	if item.Item.CostDetails == nil {
+		setLegacyEventCost(&item.Item)
+	}
+	item.StartDate = model.ParseLegacyEventDate(item.Item.StartDate)
+	item.EndDate = model.ParseLegacyEventDate(item.Item.EndDate)
+	item.GeoLocation = nil
+	if item.Item.Location != nil {
+		item.GeoLocation = model.NewGeoJSONPoint(item.Item.Location.Latitude, item.Item.Location.Longitude)
+	}
+}
+
+// setLegacyEventDescription sanitizes the event long description and sets the formats derived from it
+func setLegacyEventDescription(event *model.LegacyEvent) {
+	description := utils.SanitizeHTML(event.LongDescription)
+
+	links := make([]model.DescriptionLink, len(description.Links))
+	for i, link := range description.Links {
+		links[i] = model.DescriptionLink{Text: link.Text, URL: link.URL}
+	}
+
+	event.LongDescription = description.HTML
+	event.DescriptionText = description.Text
+	event.DescriptionMarkdown = description.Markdown
+	event.DescriptionLinks = links
+}
+
 func (e eventsLogic) initializeDB() {
 	e.logger.Info("InitializeLegacyLocations started")
 	defer e.logger.Info("InitializeLegacyLocations ended")
@@ -140,6 +244,10 @@ func (e eventsLogic) process() {
 
 	//process work
 	e.processWebToolsEvents()
+	e.processSidearmEvents()
+
+	//archive the ended events from all sources
+	e.archiveEndedEvents(nil)
 
 	//generate new processing after 24 hours
 	duration := time.Hour * 24
@@ -159,24 +267,57 @@ func (e eventsLogic) process() {
 }
 
 func (e eventsLogic) processWebToolsEvents() {
+	//the web tools events belong to the org and app the feed is registered to
+	registration := e.app.webToolsRegistration
+
+	//the sync run id is the actor of the changes in the events history
+	report := model.WebToolsSyncReport{ID: uuid.NewString(), OrgID: registration.OrgID, AppID: registration.AppID,
+		Warnings: []string{}, FailedPages: []int{}, DateStarted: time.Now()}
+	e.logger.Infof("web tools sync run %s", report.ID)
+
+	err := e.syncWebToolsEvents(&report)
+	if err != nil {
+		e.logger.Errorf("error on web tools sync run %s - %s", report.ID, err)
+		report.Error = err.Error()
+		if len(report.Status) == 0 {
+			report.Status = model.WebToolsSyncFailed
+		}
+	} else {
+		report.Status = model.WebToolsSyncSucceeded
+	}
+	report.DateFinished = time.Now()
+	for _, warning := range report.Warnings {
+		e.logger.Warnf("web tools sync run %s - %s", report.ID, warning)
+	}
+
+	err = e.app.storage.InsertWebToolsSyncReport(report)
+	if err != nil {
+		e.logger.Errorf("error on saving web tools sync report %s - %s", report.ID, err)
+	}
+}
+
+// syncWebToolsEvents replaces the stored web tools events with the ones the feed gives.
+// Nothing is changed when the feed is not completely loaded.
+func (e eventsLogic) syncWebToolsEvents(report *model.WebToolsSyncReport) error {
 	//load all web tools events
-	allWebToolsEvents, err := e.loadAllWebToolsEvents()
+	allWebToolsEvents, err := e.loadAllWebToolsEvents(report)
 	if err != nil {
 		e.logger.Errorf("error on loading web tools events - %s", err)
-		return
+		return err
 	}
 
 	// Keep only one instance per unique EventID to prevent duplicates in the app.
 	allWebToolsEvents, err = e.preventDuplicateEvents(allWebToolsEvents)
 	if err != nil {
 		e.logger.Errorf("error on prevent duplicate web tools events - %s", err)
-		return
+		return err
 	}
 
 	webToolsCount := len(allWebToolsEvents)
+	report.EventsCount = webToolsCount
 	if webToolsCount == 0 {
 		e.logger.Error("web tools are nil")
-		return
+		return errors.New("no web tools events loaded")
 	}
 
 	e.logger.Infof("we loaded %d web tools events", webToolsCount)
@@ -185,36 +326,48 @@ func (e eventsLogic) processWebToolsEvents() {
 	imagesData, err := e.processImages(allWebToolsEvents)
 	if err != nil {
 		e.logger.Errorf("error on processing images - %s", err)
-		return
+		return err
 	}
 
 	//process the locations before the main processing
 	locationsData, err := e.processLocations(allWebToolsEvents)
 	if err != nil {
 		e.logger.Errorf("error on processing locations - %s", err)
-		return
+		return err
 	}
 
+	//the campus buildings and their aliases the locations are matched against
+	buildingsData, aliasesData := e.processBuildings()
+
 	now := time.Now()
+	registration := e.app.webToolsRegistration
 
 	//in transaction
 	err = e.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		//archive the ended webtools events before removing them, the webtools feed may not give them anymore
+		err := e.archiveEndedEvents(context)
+		if err != nil {
+			return err
+		}
+
 		//1. first we must keep the events ids for the webtools events(sourceId = "0") because we will remove all of them and later recreated with the new ones
-		webtoolsItemsFromStorage, err := e.app.storage.FindLegacyEventItemsBySourceID(context, "0")
+		webtoolsItemsFromStorage, err := e.app.storage.FindLegacyEventItemsBySourceID(context, registration.OrgID, registration.AppID, "0")
 		if err != nil {
 			e.logger.Errorf("error on loading webtools events from the storage - %s", err)
 			return err
 		}
 
 		existingLegacyIdsMap := make(map[string]string)
+		existingItemsMap := make(map[string]model.LegacyEventItem)
 		for _, w := range webtoolsItemsFromStorage {
 			if len(w.Item.DataSourceEventID) > 0 {
 				existingLegacyIdsMap[w.Item.DataSourceEventID] = w.Item.ID
 			}
+			existingItemsMap[w.Item.ID] = w
 		}
 
 		//2. once we already have the ids then we have to remove all webtools events from the database
-		err = e.app.storage.DeleteLegacyEventsBySourceID(context, "0")
+		err = e.app.storage.DeleteLegacyEventsBySourceID(context, registration.OrgID, registration.AppID, "0")
 		if err != nil {
 			e.logger.Errorf("error on deleting legacy events from the storage - %s", err)
 			return err
@@ -230,6 +383,7 @@ func (e eventsLogic) processWebToolsEvents() {
 		}
 
 		//4. now you have to convert all allWebToolsEvents into legacy events
+		archiveBefore := now.Add(-e.app.eventsRetention.ArchiveAfter)
 		newLegacyEvents := []model.LegacyEventItem{}
 		for _, wt := range allWebToolsEvents {
 
@@ -242,11 +396,48 @@ func (e eventsLogic) processWebToolsEvents() {
 				return errors.New("status not found for " + wt.EventID)
 			}
 
-			le := e.constructLegacyEvent(wt, id, status, now, imagesData, locationsData)
+			le := e.constructLegacyEvent(wt, id, status, now, imagesData, locationsData, buildingsData, aliasesData)
+			le.OrgID = registration.OrgID
+			le.AppID = registration.AppID
+			if le.EndedBefore(archiveBefore) {
+				//the ended events are in the archive
+				continue
+			}
 			newLegacyEvents = append(newLegacyEvents, le)
 		}
 
-		//5. store all them in the database
+		//5. record what has changed in the events history
+		history := []model.LegacyEventHistoryItem{}
+		for i, le := range newLegacyEvents {
+			var previous *model.LegacyEventItem
+			if existing, ok := existingItemsMap[le.Item.ID]; ok {
+				previous = &existing
+				newLegacyEvents[i].Version = existing.Version
+				delete(existingItemsMap, le.Item.ID)
+			}
+
+			historyItem := newLegacyEventHistoryItem(previous, &le, le.SyncProcessSource, report.ID, now)
+			if historyItem != nil {
+				newLegacyEvents[i].Version = historyItem.Version
+				history = append(history, *historyItem)
+			}
+		}
+		for _, removed := range existingItemsMap {
+			//not given by web tools anymore
+			historyItem := newLegacyEventHistoryItem(&removed, nil, removed.SyncProcessSource, report.ID, now)
+			history = append(history, *historyItem)
+		}
+		report.CreatedCount, report.UpdatedCount, report.DeletedCount = countLegacyEventHistoryOperations(history)
+		err = e.app.storage.InsertLegacyEventsHistory(context, history)
+		if err != nil {
+			e.logger.Errorf("error on saving events history to the storage - %s", err)
+			return err
+		}
+
+		//6. store all them in the database
+		if len(newLegacyEvents) == 0 {
+			return nil
+		}
 		_, err = e.app.storage.InsertLegacyEvents(context, newLegacyEvents)
 		if err != nil {
 			e.logger.Errorf("error on saving events to the storage - %s", err)
@@ -259,8 +450,9 @@ func (e eventsLogic) processWebToolsEvents() {
 
 	if err != nil {
 		e.logger.Errorf("error performing transaction - %s", err)
-		return
+		return err
 	}
+	return nil
 }
 
 func (e eventsLogic) processImages(allWebtoolsEvents []model.WebToolsEvent) ([]model.ContentImagesURL, error) {
@@ -334,6 +526,11 @@ func (e eventsLogic) getNotProcessedEvents(eventsForProcessing []model.WebToolsE
 
 	processedMap := make(map[string]bool) // map to keep track of processed events
 	for _, item := range allProcessed {
+		//images processed before the renditions were introduced are processed again only when backfilling them,
+		//the images of the new events get their renditions anyway
+		if len(item.Images) == 0 && e.app.imageRenditionsBackfill {
+			continue
+		}
 		processedMap[item.ID] = true
 	}
 
@@ -362,7 +559,7 @@ func (e eventsLogic) applyProcessImages(item []model.WebToolsEvent) error {
 		}
 
 		//mark as processed
-		err = e.app.storage.InsertImageItem(*res)
+		err = e.app.storage.SaveImageItem(*res)
 		if err != nil {
 			return err
 		}
@@ -380,7 +577,7 @@ func (e eventsLogic) applyRules(context storage.TransactionContext, allWebtoolsE
 	statuses := map[string]model.LegacyEventStatus{}
 
 	//we need to manage the black list items
-	blacklistsItems, err := e.app.storage.FindWebtoolsBlacklistData(context)
+	blacklistsItems, err := e.app.storage.FindWebtoolsBlacklistData(context, e.app.webToolsRegistration.OrgID, e.app.webToolsRegistration.AppID)
 	if err != nil {
 		return nil, err
 	}
@@ -486,48 +683,92 @@ func (e eventsLogic) prepareID(currentWTEventID string, existingLegacyIdsMap map
 	return uuid.NewString()
 }
 
-func (e eventsLogic) loadAllWebToolsEvents() ([]model.WebToolsEvent, error) {
+// webToolsPage represents a loaded web tools feed page
+type webToolsPage struct {
+	number   int
+	response *model.WebToolsResponse //nil when there is no such page
+	err      error
+}
+
+// loadAllWebToolsEvents loads the feed pages until an empty one, webToolsParallelPages at a time.
+// It gives an error if any page has failed or the feed has more than webToolsMaxPages pages, so the events would be incomplete.
+func (e eventsLogic) loadAllWebToolsEvents(report *model.WebToolsSyncReport) ([]model.WebToolsEvent, error) {
 	allWebToolsEvents := []model.WebToolsEvent{}
 
-	page := 0
-	for {
-		resp, err := http.Get(fmt.Sprintf("https://xml.calendars.illinois.edu/eventXML17/6991.xml?pageNumber=%d", page))
-		if err != nil {
-			log.Printf("error: %s", err)
-			break
+	for first := 0; ; first += webToolsParallelPages {
+		if first >= webToolsMaxPages {
+			report.Status = model.WebToolsSyncIncomplete
+			report.AddWarning(fmt.Sprintf("the feed has more than %d pages", webToolsMaxPages))
+			return nil, fmt.Errorf("web tools feed has more than %d pages", webToolsMaxPages)
 		}
 
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			log.Printf("error: %s", err)
-			break
+		//load the next pages in parallel
+		pages := make([]webToolsPage, min(webToolsParallelPages, webToolsMaxPages-first))
+		var wg sync.WaitGroup
+		for i := range pages {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				pages[i] = e.loadWebToolsPage(first + i)
+			}(i)
 		}
+		wg.Wait()
 
-		var responseData model.WebToolsResponse
-		err = xml.Unmarshal(data, &responseData)
-		if err != nil {
-			log.Printf("error: %s", err)
-			break
-		}
+		for _, page := range pages {
+			if page.err != nil {
+				e.logger.Errorf("error on loading web tools page %d - %s", page.number, page.err)
+				report.FailedPages = append(report.FailedPages, page.number)
+				continue
+			}
+			if page.response == nil || len(page.response.WebToolsEvents) == 0 {
+				//no more pages
+				if len(report.FailedPages) > 0 {
+					report.Status = model.WebToolsSyncIncomplete
+					return nil, fmt.Errorf("web tools pages %v have failed", report.FailedPages)
+				}
+				return allWebToolsEvents, nil
+			}
+
+			count := len(page.response.WebToolsEvents)
+			e.logger.Infof("page:%d events count: %d", page.number, count)
+			report.PagesCount++
 
-		count := len(responseData.WebToolsEvents)
-		log.Printf("page:%d events count: %d", page, count)
+			//the feed tells when it is going to be replaced
+			if len(strings.TrimSpace(page.response.Deprecated)) > 0 {
+				report.AddWarning("the feed is deprecated: " + strings.TrimSpace(page.response.Deprecated))
+			}
+			if len(strings.TrimSpace(page.response.EndOfService)) > 0 {
+				report.AddWarning("the feed end of service date: " + strings.TrimSpace(page.response.EndOfService))
+			}
 
-		//io.Write(file)
-		if count == 0 {
-			break
+			allWebToolsEvents = append(allWebToolsEvents, page.response.WebToolsEvents...)
 		}
-		page++
 
-		currentItems := responseData.WebToolsEvents
-		allWebToolsEvents = append(allWebToolsEvents, currentItems...)
+		if len(report.FailedPages) > 0 {
+			//the failed pages may hide the end of the feed
+			report.Status = model.WebToolsSyncIncomplete
+			return nil, fmt.Errorf("web tools pages %v have failed", report.FailedPages)
+		}
+	}
+}
+
+func (e eventsLogic) loadWebToolsPage(number int) webToolsPage {
+	data, err := e.webToolsFeed.LoadPage(number)
+	if err != nil || data == nil {
+		return webToolsPage{number: number, err: err}
 	}
 
-	return allWebToolsEvents, nil
+	var responseData model.WebToolsResponse
+	err = xml.Unmarshal(data, &responseData)
+	if err != nil {
+		return webToolsPage{number: number, err: err}
+	}
+	return webToolsPage{number: number, response: &responseData}
 }
 
 func (e eventsLogic) constructLegacyEvent(g model.WebToolsEvent, id string, status model.LegacyEventStatus,
-	now time.Time, imagesData []model.ContentImagesURL, locationsData []model.LegacyLocation) model.LegacyEventItem {
+	now time.Time, imagesData []model.ContentImagesURL, locationsData []model.LegacyLocation, buildingsData []model.Building,
+	aliasesData []model.BuildingAlias) model.LegacyEventItem {
 
 	syncProcessSource := "webtools-direct"
 
@@ -540,12 +781,11 @@ func (e eventsLogic) constructLegacyEvent(g model.WebToolsEvent, id string, stat
 		costFree = true
 	}
 
-	var isVirtual bool
-	if g.VirtualEvent == "false" {
-		isVirtual = false
-	} else if g.VirtualEvent == "true" {
-		isVirtual = true
-	}
+	//attendance mode - the events with a virtual url can be attended online even if not flagged as virtual
+	virtualEventURL := strings.TrimSpace(g.VirtualEventURL)
+	isVirtual := g.VirtualEvent == "true" || len(virtualEventURL) > 0
+	inPerson := g.InPersonEvent == "true" || (g.InPersonEvent != "false" && !isVirtual)
+	attendanceMode := model.NewLegacyEventAttendanceMode(inPerson, isVirtual)
 
 	var Recurrence bool
 	if g.Recurrence == "false" {
@@ -616,23 +856,31 @@ func (e eventsLogic) constructLegacyEvent(g model.WebToolsEvent, id string, stat
 
 	startDateStr := ""
 	endDateStr := ""
+	var startDateUTC, endDateUTC *time.Time
 
 	if startDateObj != nil {
 		startDateStr = startDateObj.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")
+		startDateTmp := startDateObj.UTC()
+		startDateUTC = &startDateTmp
 	}
 	if endDateObj != nil {
 		endDateStr = endDateObj.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")
+		endDateTmp := endDateObj.UTC()
+		endDateUTC = &endDateTmp
 	}
 
 	//end - start date + end date (+all day)
 
-	//tags
+	//tags - the topics names
 	var tags *[]string
-	if len(g.Topic) > 0 {
-		tagsList := []string{}
-		for _, t := range g.Topic {
-			tagsList = append(tagsList, t.Name)
+	var tagsList []string
+	for _, t := range g.Topic {
+		name := strings.TrimSpace(t.Name)
+		if len(name) > 0 && !slices.Contains(tagsList, name) {
+			tagsList = append(tagsList, name)
 		}
+	}
+	if len(tagsList) != 0 {
 		tags = &tagsList
 	}
 	//end tags
@@ -663,8 +911,9 @@ func (e eventsLogic) constructLegacyEvent(g model.WebToolsEvent, id string, stat
 	//end target audience
 
 	//image url
-	imageURL := e.getImageURL(g.EventID, imagesData)
+	imageURL, images := e.getImageURLs(g.EventID, imagesData)
 	loc := constructLocation(g, locationsData)
+	setLocationBuilding(loc, buildingsData, aliasesData)
 
 	//category
 	lowerCategory := strings.ToLower(g.EventType)
@@ -673,24 +922,29 @@ func (e eventsLogic) constructLegacyEvent(g model.WebToolsEvent, id string, stat
 		category = foundCategory
 	}
 
+	event := model.LegacyEvent{ID: id, Category: category, CreatedBy: createdBy,
+		OriginatingCalendarID: g.OriginatingCalendarID, OriginatingCalendarName: g.OriginatingCalendarName,
+		IsVirtial: isVirtual, VirtualEventURL: virtualEventURL, AttendanceMode: attendanceMode, DataModified: modifiedDate, DateCreated: createdDate,
+		Sponsor: g.Sponsor, Speaker: strings.TrimSpace(g.Speaker), Title: g.Title, CalendarID: g.CalendarID, SourceID: "0", AllDay: allDay, IsEventFree: costFree, Cost: g.Cost, LongDescription: g.Description,
+		TitleURL: g.TitleURL, RegistrationURL: g.RegistrationURL, RecurringFlag: Recurrence, IcalURL: icalURL, OutlookURL: outlookURL,
+		RecurrenceID: recurrenceID, Location: loc, Contacts: contatsLegacy,
+		DataSourceEventID: g.EventID, StartDate: startDateStr, EndDate: endDateStr,
+		Tags: tags, TargetAudience: targetAudience, ImageURL: imageURL, Images: images}
+	setLegacyEventDescription(&event)
+	setLegacyEventCost(&event)
+
 	return model.LegacyEventItem{SyncProcessSource: syncProcessSource, SyncDate: now, Status: status,
-		Item: model.LegacyEvent{ID: id, Category: category, CreatedBy: createdBy,
-			OriginatingCalendarID: g.OriginatingCalendarID, OriginatingCalendarName: g.OriginatingCalendarName,
-			IsVirtial: isVirtual, DataModified: modifiedDate, DateCreated: createdDate,
-			Sponsor: g.Sponsor, Title: g.Title, CalendarID: g.CalendarID, SourceID: "0", AllDay: allDay, IsEventFree: costFree, Cost: g.Cost, LongDescription: g.Description,
-			TitleURL: g.TitleURL, RegistrationURL: g.RegistrationURL, RecurringFlag: Recurrence, IcalURL: icalURL, OutlookURL: outlookURL,
-			RecurrenceID: recurrenceID, Location: loc, Contacts: contatsLegacy,
-			DataSourceEventID: g.EventID, StartDate: startDateStr, EndDate: endDateStr,
-			Tags: tags, TargetAudience: targetAudience, ImageURL: imageURL}}
+		StartDate: startDateUTC, EndDate: endDateUTC, GeoLocation: model.NewGeoJSONPoint(loc.Latitude, loc.Longitude),
+		Item: event}
 }
 
-func (e eventsLogic) getImageURL(eventID string, imageData []model.ContentImagesURL) *string {
+func (e eventsLogic) getImageURLs(eventID string, imageData []model.ContentImagesURL) (*string, map[string]string) {
 	for _, image := range imageData {
 		if image.ID == eventID {
-			return &image.ImageURL
+			return &image.ImageURL, image.Images
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 func (e eventsLogic) formatDate(wtDate string) string {
@@ -709,6 +963,16 @@ func (e eventsLogic) formatDate(wtDate string) string {
 	return result
 }
 
+// processBuildings loads the campus buildings with the admin managed aliases, nil if they cannot be loaded - the locations are not matched then
+func (e eventsLogic) processBuildings() ([]model.Building, []model.BuildingAlias) {
+	buildings, err := e.app.Client.GetBuildings()
+	if err != nil || buildings == nil {
+		e.logger.Warnf("error on loading the campus buildings, the event locations are not matched - %v", err)
+		return nil, nil
+	}
+	return *buildings, e.app.buildingsLogic.getAliases()
+}
+
 func (e eventsLogic) processLocations(allWebtoolsEvents []model.WebToolsEvent) ([]model.LegacyLocation, error) {
 	//get the locations for processing
 	forProcessingLocations, err := e.getLocationsForProcessing(allWebtoolsEvents)
@@ -912,7 +1176,8 @@ func contactsToDef(items []model.ContactLegacy) []model.ContactLegacy {
 }
 
 // newAppEventsLogic creates new appShared
-func newAppEventsLogic(app *Application, eventsBBAdapter EventsBBAdapter, geoBBAdapter GeoAdapter, logger logs.Logger) eventsLogic {
+func newAppEventsLogic(app *Application, eventsBBAdapter EventsBBAdapter, geoBBAdapter GeoAdapter, webToolsFeed WebToolsFeed, sidearmFeed SidearmFeed, logger logs.Logger) eventsLogic {
 	timerDone := make(chan bool)
-	return eventsLogic{app: app, eventsBBAdapter: eventsBBAdapter, geoBBAdapter: geoBBAdapter, timerDone: timerDone, logger: logger}
+	return eventsLogic{app: app, eventsBBAdapter: eventsBBAdapter, geoBBAdapter: geoBBAdapter, webToolsFeed: webToolsFeed, sidearmFeed: sidearmFeed,
+		timerDone: timerDone, logger: logger}
 }
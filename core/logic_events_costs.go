@@ -0,0 +1,180 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// costAmountPattern matches an amount like $10, $7.50, 10 USD or €5
+var costAmountPattern = regexp.MustCompile(`(?i)([$€£])\s*(\d{1,3}(?:,\d{3})+(?:\.\d{1,2})?|\d+(?:\.\d{1,2})?)|(\d{1,3}(?:,\d{3})+(?:\.\d{1,2})?|\d+(?:\.\d{1,2})?)\s*(usd|eur|gbp|dollars?)\b`)
+
+// costFreePattern matches the free cost words
+var costFreePattern = regexp.MustCompile(`(?i)\b(free|no charge|no cost|complimentary)\b`)
+
+// costVariesPattern matches the costs which vary or are donations
+var costVariesPattern = regexp.MustCompile(`(?i)\b(varies|vary|variable|donations?|pay what|suggested|sliding)\b`)
+
+// costSeparatorPattern separates the cost tiers
+var costSeparatorPattern = regexp.MustCompile(`,\s|[;|\n]|\s/\s`)
+
+// costAndPattern separates the cost tiers joined by "and" like "$5 for students and $10 for the public"
+var costAndPattern = regexp.MustCompile(`(?i)\sand\s`)
+
+// costCurrencies gives the currency codes by symbol or word
+var costCurrencies = map[string]string{"$": "USD", "€": "EUR", "£": "GBP", "usd": "USD", "eur": "EUR", "gbp": "GBP", "dollar": "USD", "dollars": "USD"}
+
+// costAudienceWords gives the audience labels by the words used in the costs, the longer words are matched first
+var costAudienceWords = []struct {
+	word     string
+	audience string
+}{
+	{"general public", model.LegacyEventCostAudiencePublic},
+	{"general admission", model.LegacyEventCostAudiencePublic},
+	{"non-students", model.LegacyEventCostAudiencePublic},
+	{"nonstudents", model.LegacyEventCostAudiencePublic},
+	{"public", model.LegacyEventCostAudiencePublic},
+	{"adults", model.LegacyEventCostAudiencePublic},
+	{"adult", model.LegacyEventCostAudiencePublic},
+	{"students", model.LegacyEventCostAudienceStudents},
+	{"student", model.LegacyEventCostAudienceStudents},
+	{"faculty", "faculty"},
+	{"staff", "staff"},
+	{"seniors", "seniors"},
+	{"senior", "seniors"},
+	{"children", "children"},
+	{"kids", "children"},
+	{"youth", "children"},
+	{"alumni", "alumni"},
+	{"members", "members"},
+	{"member", "members"},
+}
+
+// parseLegacyEventCost parses cost texts like "$5 students, $10 general public", "Free for students" or "Suggested donation $10".
+// The free flag gives a free tier when the text does not give any tier.
+func parseLegacyEventCost(cost string, free bool) model.LegacyEventCost {
+	result := model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{}, Varies: costVariesPattern.MatchString(cost)}
+
+	for _, part := range costParts(cost) {
+		var tier *model.LegacyEventCostTier
+		amounts := costAmountPattern.FindAllStringSubmatch(part, -1)
+		if len(amounts) == 0 {
+			if !costFreePattern.MatchString(part) {
+				continue
+			}
+			tier = &model.LegacyEventCostTier{Amount: 0, Currency: "USD"}
+		}
+
+		//a range gives its lowest amount, the cost varies
+		if len(amounts) > 1 {
+			result.Varies = true
+		}
+		for _, groups := range amounts {
+			amount, currency := costAmount(groups)
+			if tier == nil || amount < tier.Amount {
+				tier = &model.LegacyEventCostTier{Amount: amount, Currency: currency}
+			}
+		}
+
+		//the same price for all the audiences of the part
+		for _, audience := range costAudiences(part) {
+			tier.Audience = audience
+			result.Tiers = append(result.Tiers, *tier)
+		}
+	}
+
+	if len(result.Tiers) == 0 && free {
+		result.Tiers = append(result.Tiers, model.LegacyEventCostTier{Amount: 0, Currency: "USD"})
+	}
+	return result
+}
+
+// costParts splits the cost text into the parts which give a tier each
+func costParts(cost string) []string {
+	var parts []string
+	for _, part := range costSeparatorPattern.Split(cost, -1) {
+		//"and" joins two tiers only when both of them give a price
+		andParts := costAndPattern.Split(part, -1)
+		split := len(andParts) > 1
+		for _, andPart := range andParts {
+			if !costAmountPattern.MatchString(andPart) && !costFreePattern.MatchString(andPart) {
+				split = false
+			}
+		}
+		if !split {
+			andParts = []string{part}
+		}
+
+		for _, andPart := range andParts {
+			if andPart = strings.TrimSpace(andPart); len(andPart) > 0 {
+				parts = append(parts, andPart)
+			}
+		}
+	}
+	return parts
+}
+
+// costAmount gives the amount and the currency of the amount pattern match
+func costAmount(groups []string) (float64, string) {
+	symbol, value := groups[1], groups[2]
+	if len(value) == 0 {
+		symbol, value = groups[4], groups[3]
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0, ""
+	}
+	return amount, costCurrencies[strings.ToLower(symbol)]
+}
+
+// costAudiences gives the audience labels of the cost tier, empty label for everyone
+func costAudiences(part string) []string {
+	var audiences []string
+	lower := strings.ToLower(part)
+	for _, item := range costAudienceWords {
+		if !strings.Contains(lower, item.word) {
+			continue
+		}
+		//"non-students" must not match "students" again
+		lower = strings.ReplaceAll(lower, item.word, " ")
+		if !slices.Contains(audiences, item.audience) {
+			audiences = append(audiences, item.audience)
+		}
+	}
+	if len(audiences) == 0 {
+		return []string{""}
+	}
+	return audiences
+}
+
+// setLegacyEventCost sets the structured cost of the event, it is free when all its tiers are free
+func setLegacyEventCost(event *model.LegacyEvent) {
+	cost := parseLegacyEventCost(event.Cost, event.IsEventFree)
+	event.CostDetails = &cost
+	if len(cost.Tiers) == 0 {
+		return
+	}
+
+	event.IsEventFree = !cost.Varies
+	for _, tier := range cost.Tiers {
+		if tier.Amount > 0 {
+			event.IsEventFree = false
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"reflect"
+	"testing"
+)
+
+func TestParseLegacyEventCost(t *testing.T) {
+	students, public := model.LegacyEventCostAudienceStudents, model.LegacyEventCostAudiencePublic
+
+	tests := []struct {
+		name string
+		cost string
+		free bool
+		want model.LegacyEventCost
+	}{
+		{name: "tiers by audience", cost: "$5 students, $10 general public",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "USD", Audience: students}, {Amount: 10, Currency: "USD", Audience: public}}}},
+		{name: "semicolon separated tiers", cost: "$5 students; $8 seniors; $12 adults",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "USD", Audience: students},
+				{Amount: 8, Currency: "USD", Audience: "seniors"}, {Amount: 12, Currency: "USD", Audience: public}}}},
+		{name: "slash separated tiers", cost: "$15 / $10 students",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 15, Currency: "USD"}, {Amount: 10, Currency: "USD", Audience: students}}}},
+		{name: "tiers joined by and", cost: "$5 for students and $10 for the public",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "USD", Audience: students}, {Amount: 10, Currency: "USD", Audience: public}}}},
+		{name: "audiences joined by and", cost: "Students and faculty $5",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "USD", Audience: students}, {Amount: 5, Currency: "USD", Audience: "faculty"}}}},
+		{name: "non-students are the public", cost: "Non-students $10, students $5",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 10, Currency: "USD", Audience: public}, {Amount: 5, Currency: "USD", Audience: students}}}},
+		{name: "cents", cost: "$7.50",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 7.5, Currency: "USD"}}}},
+		{name: "thousands", cost: "$1,250 per table",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 1250, Currency: "USD"}}}},
+		{name: "currency code", cost: "10 USD",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 10, Currency: "USD"}}}},
+		{name: "dollars word", cost: "20 dollars for adults",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 20, Currency: "USD", Audience: public}}}},
+		{name: "euro", cost: "€5 members",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "EUR", Audience: "members"}}}},
+		{name: "pound", cost: "£3",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 3, Currency: "GBP"}}}},
+		{name: "range gives the lowest amount", cost: "$10-$20",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 10, Currency: "USD"}}, Varies: true}},
+		{name: "free", cost: "Free",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD"}}}},
+		{name: "free for an audience", cost: "Free for students",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD", Audience: students}}}},
+		{name: "no charge", cost: "No charge",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD"}}}},
+		{name: "complimentary", cost: "Complimentary",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD"}}}},
+		{name: "free and paid tiers", cost: "Free for students, $10 general admission",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD", Audience: students}, {Amount: 10, Currency: "USD", Audience: public}}}},
+		{name: "suggested donation", cost: "Suggested donation $10",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 10, Currency: "USD"}}, Varies: true}},
+		{name: "pay what you can", cost: "Pay what you can",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{}, Varies: true}},
+		{name: "no amount", cost: "TBD",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{}}},
+		{name: "empty", cost: "",
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{}}},
+		{name: "empty free", cost: "", free: true,
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 0, Currency: "USD"}}}},
+		{name: "free flag with tiers", cost: "$5", free: true,
+			want: model.LegacyEventCost{Tiers: []model.LegacyEventCostTier{{Amount: 5, Currency: "USD"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLegacyEventCost(tt.cost, tt.free)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLegacyEventCost(%q, %v) = %+v, want %+v", tt.cost, tt.free, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLegacyEventCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		cost     string
+		free     bool
+		wantFree bool
+	}{
+		{name: "free", cost: "Free", wantFree: true},
+		{name: "paid", cost: "$5", free: true, wantFree: false},
+		{name: "free for some audiences", cost: "Free for students, $10 general public", wantFree: false},
+		{name: "donation", cost: "Suggested donation", free: true, wantFree: false},
+		{name: "no tiers keeps the flag", cost: "TBD", free: true, wantFree: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := model.LegacyEvent{Cost: tt.cost, IsEventFree: tt.free}
+			setLegacyEventCost(&event)
+			if event.CostDetails == nil {
+				t.Fatal("CostDetails = nil")
+			}
+			if event.IsEventFree != tt.wantFree {
+				t.Errorf("IsEventFree = %v, want %v", event.IsEventFree, tt.wantFree)
+			}
+		})
+	}
+}
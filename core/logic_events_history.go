@@ -0,0 +1,135 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// legacyEventHistoryIgnoredFields are not tracked as they change on every sync or are derived from the tracked fields
+var legacyEventHistoryIgnoredFields = map[string]bool{
+	"sync_date": true, "start_date": true, "end_date": true, "geo_location": true, "create_info": true, "version": true,
+	"item.descriptionText": true, "item.descriptionMarkdown": true, "item.descriptionLinks": true,
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// newLegacyEventHistoryItem creates the history record for the event write, nil if nothing has changed.
+// The previous event is nil for created event and the current one is nil for deleted event.
+func newLegacyEventHistoryItem(previous *model.LegacyEventItem, current *model.LegacyEventItem, source string, actor string, now time.Time) *model.LegacyEventHistoryItem {
+	operation := model.LegacyEventHistoryUpdated
+	empty := model.LegacyEventItem{}
+	version := 1
+	if previous == nil {
+		operation = model.LegacyEventHistoryCreated
+		previous = &empty
+	} else {
+		version = previous.Version + 1
+	}
+	if current == nil {
+		operation = model.LegacyEventHistoryDeleted
+		current = &empty
+	}
+
+	changes := []model.LegacyEventFieldChange{}
+	diffLegacyEventValues("", reflect.ValueOf(*previous), reflect.ValueOf(*current), legacyEventHistoryIgnoredFields, &changes)
+	if operation == model.LegacyEventHistoryUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	event := current
+	if operation == model.LegacyEventHistoryDeleted {
+		event = previous
+	}
+	return &model.LegacyEventHistoryItem{ID: uuid.NewString(), OrgID: event.OrgID, AppID: event.AppID, EventID: event.Item.ID,
+		Version: version, Operation: operation, Source: source, Actor: actor, Changes: changes, DateCreated: now}
+}
+
+// countLegacyEventHistoryOperations gives the number of the created, updated and deleted events
+func countLegacyEventHistoryOperations(history []model.LegacyEventHistoryItem) (int, int, int) {
+	var created, updated, deleted int
+	for _, item := range history {
+		switch item.Operation {
+		case model.LegacyEventHistoryCreated:
+			created++
+		case model.LegacyEventHistoryUpdated:
+			updated++
+		case model.LegacyEventHistoryDeleted:
+			deleted++
+		}
+	}
+	return created, updated, deleted
+}
+
+// diffLegacyEventValues adds the changes of the fields named by their storage paths, except the ignored ones
+func diffLegacyEventValues(path string, previous reflect.Value, current reflect.Value, ignoredFields map[string]bool, changes *[]model.LegacyEventFieldChange) {
+	if ignoredFields[path] {
+		return
+	}
+
+	//compare the nested structures field by field
+	if previous.Kind() == reflect.Pointer && previous.Type().Elem().Kind() == reflect.Struct && previous.Type().Elem() != timeType {
+		if previous.IsNil() && current.IsNil() {
+			return
+		}
+		previous = legacyEventStructValue(previous)
+		current = legacyEventStructValue(current)
+	}
+	if previous.Kind() == reflect.Struct && previous.Type() != timeType {
+		for i := 0; i < previous.NumField(); i++ {
+			name := strings.Split(previous.Type().Field(i).Tag.Get("bson"), ",")[0]
+			if len(path) > 0 {
+				name = path + "." + name
+			}
+			diffLegacyEventValues(name, previous.Field(i), current.Field(i), ignoredFields, changes)
+		}
+		return
+	}
+
+	previousValue := legacyEventFieldValue(previous)
+	currentValue := legacyEventFieldValue(current)
+	if !reflect.DeepEqual(previousValue, currentValue) {
+		*changes = append(*changes, model.LegacyEventFieldChange{Field: path, Old: previousValue, New: currentValue})
+	}
+}
+
+// legacyEventStructValue gives the structure the pointer points to, empty structure for nil pointer
+func legacyEventStructValue(value reflect.Value) reflect.Value {
+	if value.IsNil() {
+		return reflect.Zero(value.Type().Elem())
+	}
+	return value.Elem()
+}
+
+// legacyEventFieldValue gives the field value, nil for nil pointers and empty lists
+func legacyEventFieldValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		return legacyEventFieldValue(value.Elem())
+	case reflect.Slice, reflect.Map:
+		if value.Len() == 0 {
+			return nil
+		}
+	}
+	return value.Interface()
+}
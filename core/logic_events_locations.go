@@ -0,0 +1,232 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// locationRoomPattern matches a room number like 116, 1002, 0216, B102 or 206A
+var locationRoomPattern = regexp.MustCompile(`^(?i)(B)?(\d{1,4})[A-Z]?$`)
+
+// locationRoomWords are the words which precede a room number
+var locationRoomWords = []string{"room", "rm", "rooms", "rms"}
+
+// parsedLocation represents the building and the rooms found in an event location description
+type parsedLocation struct {
+	building string
+	rooms    []string
+}
+
+// parseLocationDescription finds the building and the rooms in descriptions like "116 Roger Adams Lab" or "Uni 206, 210, 211".
+// The first part gives the building and the rooms, the next parts give only more rooms.
+func parseLocationDescription(description string) parsedLocation {
+	result := parsedLocation{}
+	for i, part := range strings.Split(description, ",") {
+		var buildingWords []string
+		var rooms []string
+		for _, word := range strings.Fields(part) {
+			word = strings.Trim(word, ".#:;()")
+			if len(word) == 0 || slices.Contains(locationRoomWords, strings.ToLower(word)) {
+				continue
+			}
+			if locationRoomPattern.MatchString(word) {
+				rooms = append(rooms, strings.ToUpper(word))
+				continue
+			}
+			buildingWords = append(buildingWords, word)
+		}
+
+		if i == 0 {
+			result.building = strings.Join(buildingWords, " ")
+		} else if len(buildingWords) > 0 {
+			//an address or a city follows
+			break
+		}
+		result.rooms = append(result.rooms, rooms...)
+	}
+	return result
+}
+
+// matchLocationBuilding finds the campus building by its name, short name, number or admin managed alias like "DCL" or "Uni High".
+// Every word of the name may be abbreviated - "Roger Adams Lab" is "Roger Adams Laboratory".
+func matchLocationBuilding(name string, buildings []model.Building, aliases []model.BuildingAlias) *model.Building {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) == 0 {
+		return nil
+	}
+	//the aliases are stored normalized like the searched text
+	normalized := strings.Join(searchWords(name), " ")
+	for _, alias := range aliases {
+		if alias.Alias != normalized {
+			continue
+		}
+		for i, building := range buildings {
+			if buildingKey(building) == alias.BuildingID {
+				return &buildings[i]
+			}
+		}
+	}
+
+	var match *model.Building
+	for i, building := range buildings {
+		if strings.EqualFold(building.Name, name) || strings.EqualFold(building.ShortName, name) || building.Number == name {
+			return &buildings[i]
+		}
+		if !matchAbbreviatedWords(strings.Fields(name), strings.Fields(strings.ToLower(building.Name))) {
+			continue
+		}
+		//the building with the fewest words matches best
+		if match == nil || len(strings.Fields(building.Name)) < len(strings.Fields(match.Name)) {
+			match = &buildings[i]
+		}
+	}
+	return match
+}
+
+// matchAbbreviatedWords tells if every word is a prefix of the corresponding full word, a single word must be a full word
+func matchAbbreviatedWords(words []string, fullWords []string) bool {
+	if len(words) == 0 || len(words) > len(fullWords) {
+		return false
+	}
+	if len(words) == 1 {
+		return len(words[0]) >= 4 && words[0] == fullWords[0]
+	}
+	for i, word := range words {
+		if !strings.HasPrefix(fullWords[i], word) {
+			return false
+		}
+	}
+	return true
+}
+
+// locationFloor derives the floor from the room number, 0 when unknown and -1 for the basement.
+// The last two digits of a three digits room number and the last three digits of a four digits one number the room on its floor,
+// the leading digits are the floor - 0216 is in the basement.
+func locationFloor(room string) int {
+	groups := locationRoomPattern.FindStringSubmatch(room)
+	if groups == nil {
+		return 0
+	}
+	if len(groups[1]) > 0 {
+		return -1
+	}
+
+	digits := groups[2]
+	roomDigits := 2
+	if len(digits) == 4 {
+		roomDigits = 3
+	}
+	if len(digits) <= roomDigits {
+		return 0
+	}
+
+	floor, err := strconv.Atoi(digits[:len(digits)-roomDigits])
+	if err != nil {
+		return 0
+	}
+	if floor == 0 {
+		return -1
+	}
+	return floor
+}
+
+// locationFloorID gives the building floor id used by the floor plans, empty if the building does not have the floor
+func locationFloorID(building model.Building, floor int) string {
+	candidates := []string{strconv.Itoa(floor)}
+	if floor < 0 {
+		candidates = []string{"B", "0", "00", "LL"}
+	}
+	if len(building.Floors) == 0 {
+		if floor > 0 {
+			return candidates[0]
+		}
+		return ""
+	}
+	for _, candidate := range candidates {
+		for _, floorID := range building.Floors {
+			if strings.EqualFold(floorID, candidate) || strings.TrimLeft(floorID, "0") == candidate {
+				return floorID
+			}
+		}
+	}
+	return ""
+}
+
+// setLocationBuilding fills the building, the floor and the rooms of the location when its description names a campus building
+func setLocationBuilding(location *model.LocationLegacy, buildings []model.Building, aliases []model.BuildingAlias) {
+	if location == nil || len(buildings) == 0 {
+		return
+	}
+
+	parsed := parseLocationDescription(location.Description)
+	building := matchLocationBuilding(parsed.building, buildings, aliases)
+	if building == nil {
+		return
+	}
+
+	location.Building = building.Name
+	location.BuildingNumber = building.Number
+	location.Rooms = parsed.rooms
+	if len(parsed.rooms) == 0 {
+		return
+	}
+	location.Room = parsed.rooms[0]
+	location.Floor = locationFloor(location.Room)
+	if location.Floor == 0 {
+		return
+	}
+
+	floorID := locationFloorID(*building, location.Floor)
+	if len(floorID) == 0 {
+		return
+	}
+	query := url.Values{}
+	query.Set("bldgid", building.Number)
+	query.Set("floor", floorID)
+	query.Set("room", location.Room)
+	location.FloorPlanPath = "/wayfinding/floorplan?" + query.Encode()
+}
+
+// highlightFloorPlanRoom shows the highlight of the room and hides the others, the floor plan is not changed if the room is not highlighted on it
+func highlightFloorPlanRoom(floorPlan *model.FloorPlan, room string) {
+	room = strings.TrimSpace(room)
+	if floorPlan == nil || len(room) == 0 {
+		return
+	}
+
+	found := false
+	for _, highlite := range floorPlan.Highlites {
+		if strings.EqualFold(highlite.Label, room) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+
+	for i, highlite := range floorPlan.Highlites {
+		floorPlan.Highlites[i].Display = "off"
+		if strings.EqualFold(highlite.Label, room) {
+			floorPlan.Highlites[i].Display = "on"
+		}
+	}
+}
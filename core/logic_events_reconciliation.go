@@ -0,0 +1,222 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/driven/storage"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// legacyEventReconciliationIgnoredFields are set only by the gateway, so they are not compared with the events BB
+var legacyEventReconciliationIgnoredFields = map[string]bool{
+	"descriptionText": true, "descriptionMarkdown": true, "descriptionLinks": true,
+	"images": true, "virtualEventUrl": true, "attendanceMode": true, "athletics": true,
+	"location.building": true, "location.floor": true, "location.room": true,
+	"location.buildingNumber": true, "location.rooms": true, "location.floorPlanPath": true,
+}
+
+// reconcileEventsBB compares the events BB legacy events with the valid gateway events of the org and app by id and data source event id.
+// The missing events are optionally created in the gateway and the extra ones in the events BB.
+// The events BB events whose ids are used by other gateway events - not valid, archived or of another org or app - are not created.
+func (e eventsLogic) reconcileEventsBB(orgID string, appID string, backfillGateway bool, backfillEventsBB bool) (*model.LegacyEventsReconciliation, error) {
+	eventsBBEvents, err := e.eventsBBAdapter.LoadAllLegacyEvents()
+	if err != nil {
+		e.logger.Errorf("error on loading the events BB legacy events - %s", err)
+		return nil, err
+	}
+
+	statuses := []string{model.LegacyEventStatusValid}
+	gatewayItems, err := e.app.storage.FindLegacyEventItems(nil, orgID, appID, nil, &statuses, nil, nil, nil)
+	if err != nil {
+		e.logger.Errorf("error on loading the gateway legacy events - %s", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	reconciliation := model.LegacyEventsReconciliation{ID: uuid.NewString(), OrgID: orgID, AppID: appID,
+		EventsBBCount: len(eventsBBEvents), GatewayCount: len(gatewayItems), Missing: []model.LegacyEventReconciliationItem{},
+		Existing: []model.LegacyEventReconciliationItem{}, Extra: []model.LegacyEventReconciliationItem{},
+		Divergent: []model.LegacyEventDivergence{}, FailedEventsBB: []model.LegacyEventReconciliationItem{}, DateCreated: now}
+
+	gatewayByID := map[string]model.LegacyEventItem{}
+	gatewayByDataSourceEventID := map[string]model.LegacyEventItem{}
+	for _, item := range gatewayItems {
+		gatewayByID[item.Item.ID] = item
+		if len(item.Item.DataSourceEventID) > 0 {
+			gatewayByDataSourceEventID[item.Item.DataSourceEventID] = item
+		}
+	}
+
+	matched := map[string]bool{}
+	unmatched := []model.LegacyEvent{}
+	for _, eventsBBEvent := range eventsBBEvents {
+		gatewayItem, found := gatewayByID[eventsBBEvent.ID]
+		if !found && len(eventsBBEvent.DataSourceEventID) > 0 {
+			gatewayItem, found = gatewayByDataSourceEventID[eventsBBEvent.DataSourceEventID]
+		}
+		if !found {
+			unmatched = append(unmatched, eventsBBEvent)
+			continue
+		}
+
+		if !matched[gatewayItem.Item.ID] {
+			matched[gatewayItem.Item.ID] = true
+			reconciliation.MatchedCount++
+		}
+		if divergence := newLegacyEventDivergence(gatewayItem.Item, eventsBBEvent); divergence != nil {
+			reconciliation.Divergent = append(reconciliation.Divergent, *divergence)
+		}
+	}
+
+	missing, err := e.splitMissingEventsBBEvents(unmatched, &reconciliation)
+	if err != nil {
+		e.logger.Errorf("error on finding the existing gateway legacy events - %s", err)
+		return nil, err
+	}
+
+	extra := []model.LegacyEvent{}
+	for _, item := range gatewayItems {
+		if !matched[item.Item.ID] {
+			extra = append(extra, item.Item)
+			reconciliation.Extra = append(reconciliation.Extra, newLegacyEventReconciliationItem(item.Item))
+		}
+	}
+
+	e.logger.Infof("events BB reconciliation %s - matched:%d missing:%d existing:%d extra:%d divergent:%d", reconciliation.ID,
+		reconciliation.MatchedCount, len(reconciliation.Missing), len(reconciliation.Existing), len(reconciliation.Extra), len(reconciliation.Divergent))
+
+	if backfillGateway && len(missing) > 0 {
+		err = e.backfillGatewayEvents(orgID, appID, missing, reconciliation.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		reconciliation.BackfilledGatewayCount = len(missing)
+	}
+
+	if backfillEventsBB {
+		//the events which could not be created are reported, so the created ones are not lost
+		for _, event := range extra {
+			err = e.eventsBBAdapter.CreateLegacyEvent(event)
+			if err != nil {
+				e.logger.Errorf("error on creating legacy event %s in the events BB - %s", event.ID, err)
+				reconciliation.FailedEventsBB = append(reconciliation.FailedEventsBB, newLegacyEventReconciliationItem(event))
+				continue
+			}
+			reconciliation.BackfilledEventsBBCount++
+		}
+	}
+
+	return &reconciliation, nil
+}
+
+// splitMissingEventsBBEvents gives the unmatched events BB events which can be created in the gateway.
+// The ones whose ids are already used by gateway events are reported as existing, the repeated ids are created once.
+func (e eventsLogic) splitMissingEventsBBEvents(unmatched []model.LegacyEvent, reconciliation *model.LegacyEventsReconciliation) ([]model.LegacyEvent, error) {
+	if len(unmatched) == 0 {
+		return []model.LegacyEvent{}, nil
+	}
+
+	ids := make([]string, len(unmatched))
+	for i, event := range unmatched {
+		ids[i] = event.ID
+	}
+	existingIDs, err := e.app.storage.FindExistingLegacyEventIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []model.LegacyEvent{}
+	added := map[string]bool{}
+	for _, event := range unmatched {
+		if existingIDs[event.ID] {
+			reconciliation.Existing = append(reconciliation.Existing, newLegacyEventReconciliationItem(event))
+			continue
+		}
+		reconciliation.Missing = append(reconciliation.Missing, newLegacyEventReconciliationItem(event))
+		if !added[event.ID] {
+			added[event.ID] = true
+			missing = append(missing, event)
+		}
+	}
+	return missing, nil
+}
+
+// backfillGatewayEvents creates the events BB events in the gateway, the reconciliation is the actor in the events history
+func (e eventsLogic) backfillGatewayEvents(orgID string, appID string, events []model.LegacyEvent, reconciliationID string, now time.Time) error {
+	syncProcessSource := "events-bb-initial"
+
+	items := make([]model.LegacyEventItem, len(events))
+	history := make([]model.LegacyEventHistoryItem, len(events))
+	for i, event := range events {
+		setLegacyEventDescription(&event)
+		item := model.LegacyEventItem{OrgID: orgID, AppID: appID, SyncProcessSource: syncProcessSource, SyncDate: now,
+			Status: model.LegacyEventStatus{Name: model.LegacyEventStatusValid}, Item: event}
+		setLegacyEventQueryFields(&item)
+
+		historyItem := newLegacyEventHistoryItem(nil, &item, syncProcessSource, reconciliationID, now)
+		item.Version = historyItem.Version
+		items[i] = item
+		history[i] = *historyItem
+	}
+
+	return e.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		err := e.app.storage.InsertLegacyEventsHistory(context, history)
+		if err != nil {
+			return err
+		}
+
+		_, err = e.app.storage.InsertLegacyEvents(context, items)
+		if err != nil {
+			e.logger.Errorf("error on backfilling the events BB legacy events - %s", err)
+			return err
+		}
+		return nil
+	}, 60000)
+}
+
+func newLegacyEventReconciliationItem(event model.LegacyEvent) model.LegacyEventReconciliationItem {
+	return model.LegacyEventReconciliationItem{ID: event.ID, DataSourceEventID: event.DataSourceEventID, Title: event.Title}
+}
+
+// newLegacyEventDivergence gives the fields which differ in the gateway event and in the events BB event, nil if they agree
+func newLegacyEventDivergence(gatewayEvent model.LegacyEvent, eventsBBEvent model.LegacyEvent) *model.LegacyEventDivergence {
+	//the gateway keeps the description sanitized
+	setLegacyEventDescription(&eventsBBEvent)
+	//the gateway parses the cost
+	setLegacyEventCost(&eventsBBEvent)
+	//the events are matched by id or by data source event id
+	eventsBBID := eventsBBEvent.ID
+	eventsBBEvent.ID = gatewayEvent.ID
+
+	changes := []model.LegacyEventFieldChange{}
+	diffLegacyEventValues("", reflect.ValueOf(gatewayEvent), reflect.ValueOf(eventsBBEvent), legacyEventReconciliationIgnoredFields, &changes)
+	if len(changes) == 0 && eventsBBID == gatewayEvent.ID {
+		return nil
+	}
+
+	differences := make([]model.LegacyEventFieldDifference, len(changes))
+	for i, change := range changes {
+		differences[i] = model.LegacyEventFieldDifference{Field: change.Field, Gateway: change.Old, EventsBB: change.New}
+	}
+	if eventsBBID != gatewayEvent.ID {
+		differences = append(differences, model.LegacyEventFieldDifference{Field: "id", Gateway: gatewayEvent.ID, EventsBB: eventsBBID})
+	}
+	return &model.LegacyEventDivergence{ID: gatewayEvent.ID, EventsBBID: eventsBBID, DataSourceEventID: gatewayEvent.DataSourceEventID,
+		Title: gatewayEvent.Title, Differences: differences}
+}
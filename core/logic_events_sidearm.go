@@ -0,0 +1,187 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/driven/storage"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	//sidearmSyncProcessSource is the sync process source of the Sidearm athletics events
+	sidearmSyncProcessSource = "sidearm-direct"
+	//sidearmSourceID is the source id of the Sidearm athletics events
+	sidearmSourceID = "2"
+)
+
+func (e eventsLogic) processSidearmEvents() {
+	//the sync run id is the actor of the changes in the events history
+	runID := uuid.NewString()
+	e.logger.Infof("sidearm sync run %s", runID)
+
+	feedURL := ""
+	conf, err := e.app.GetEnvConfigs()
+	if err != nil {
+		e.logger.Warnf("error on loading the env configs for the sidearm feed url - %s", err)
+	} else {
+		feedURL = conf.SidearmFeedURL
+	}
+
+	games, err := e.sidearmFeed.LoadGames(feedURL)
+	if err != nil {
+		e.logger.Errorf("error on sidearm sync run %s - %s", runID, err)
+		return
+	}
+	if games == nil {
+		e.logger.Info("the sidearm feed is not configured")
+		return
+	}
+
+	err = e.syncSidearmEvents(games, runID)
+	if err != nil {
+		e.logger.Errorf("error on sidearm sync run %s - %s", runID, err)
+	}
+}
+
+// syncSidearmEvents replaces the stored Sidearm events with the games the feed gives.
+// Nothing is changed when the feed gives no games.
+func (e eventsLogic) syncSidearmEvents(games []model.SidearmGame, runID string) error {
+	if len(games) == 0 {
+		return errors.New("no sidearm games loaded")
+	}
+	e.logger.Infof("we loaded %d sidearm games", len(games))
+
+	now := time.Now()
+	//the sidearm events belong to the org and app of the web tools feed
+	registration := e.app.webToolsRegistration
+
+	return e.app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		//archive the ended sidearm events before removing them, the web tools sync may have failed before archiving them
+		err := e.archiveEndedEvents(context)
+		if err != nil {
+			return err
+		}
+
+		//1. keep the ids of the stored events, the events of the same games keep their ids
+		itemsFromStorage, err := e.app.storage.FindLegacyEventItemsBySourceID(context, registration.OrgID, registration.AppID, sidearmSourceID)
+		if err != nil {
+			e.logger.Errorf("error on loading sidearm events from the storage - %s", err)
+			return err
+		}
+
+		existingItemsMap := make(map[string]model.LegacyEventItem)
+		for _, item := range itemsFromStorage {
+			existingItemsMap[item.Item.DataSourceEventID] = item
+		}
+
+		//2. remove the stored events, they are recreated from the feed
+		err = e.app.storage.DeleteLegacyEventsBySourceID(context, registration.OrgID, registration.AppID, sidearmSourceID)
+		if err != nil {
+			e.logger.Errorf("error on deleting sidearm events from the storage - %s", err)
+			return err
+		}
+
+		//3. convert the games into legacy events and record what has changed in the events history
+		archiveBefore := now.Add(-e.app.eventsRetention.ArchiveAfter)
+		newLegacyEvents := []model.LegacyEventItem{}
+		history := []model.LegacyEventHistoryItem{}
+		for _, game := range games {
+			var previous *model.LegacyEventItem
+			id := uuid.NewString()
+			if existing, ok := existingItemsMap[game.ID]; ok {
+				previous = &existing
+				id = existing.Item.ID
+				delete(existingItemsMap, game.ID)
+			}
+
+			le := constructSidearmLegacyEvent(game, id, now)
+			le.OrgID = registration.OrgID
+			le.AppID = registration.AppID
+			if le.EndedBefore(archiveBefore) {
+				//the ended events have been archived at the start of the transaction
+				continue
+			}
+
+			if previous != nil {
+				le.Version = previous.Version
+			}
+			historyItem := newLegacyEventHistoryItem(previous, &le, sidearmSyncProcessSource, runID, now)
+			if historyItem != nil {
+				le.Version = historyItem.Version
+				history = append(history, *historyItem)
+			}
+			newLegacyEvents = append(newLegacyEvents, le)
+		}
+		for _, removed := range existingItemsMap {
+			//not given by the feed anymore
+			historyItem := newLegacyEventHistoryItem(&removed, nil, sidearmSyncProcessSource, runID, now)
+			history = append(history, *historyItem)
+		}
+
+		created, updated, deleted := countLegacyEventHistoryOperations(history)
+		e.logger.Infof("sidearm sync run %s - created:%d updated:%d deleted:%d", runID, created, updated, deleted)
+		err = e.app.storage.InsertLegacyEventsHistory(context, history)
+		if err != nil {
+			e.logger.Errorf("error on saving sidearm events history to the storage - %s", err)
+			return err
+		}
+
+		//4. store the events
+		if len(newLegacyEvents) == 0 {
+			return nil
+		}
+		_, err = e.app.storage.InsertLegacyEvents(context, newLegacyEvents)
+		if err != nil {
+			e.logger.Errorf("error on saving sidearm events to the storage - %s", err)
+			return err
+		}
+		return nil
+	}, 180000)
+}
+
+// constructSidearmLegacyEvent converts the Sidearm game into a legacy event
+func constructSidearmLegacyEvent(game model.SidearmGame, id string, now time.Time) model.LegacyEventItem {
+	title := fmt.Sprintf("%s vs. %s", game.Sport, game.Opponent)
+	if game.HomeAway == model.LegacyEventAthleticsAway {
+		title = fmt.Sprintf("%s at %s", game.Sport, game.Opponent)
+	}
+
+	var location *model.LocationLegacy
+	if len(game.Venue) > 0 || len(game.City) > 0 {
+		description := strings.Trim(strings.Join([]string{game.Venue, game.City}, ", "), ", ")
+		location = &model.LocationLegacy{Description: description, Building: game.Venue, Address: game.City}
+	}
+
+	startDate := game.StartDate.UTC()
+	athletics := model.LegacyEventAthletics{Sport: game.Sport, Opponent: game.Opponent, HomeAway: game.HomeAway,
+		Venue: game.Venue, TicketURL: game.TicketURL}
+
+	event := model.LegacyEvent{ID: id, Category: whitelistCategoryMap["sidearm"], Subcategory: game.Sport, SourceID: sidearmSourceID,
+		DataSourceEventID: game.ID, Title: title, TitleURL: game.GameURL, RegistrationURL: game.TicketURL, Location: location,
+		AllDay: game.AllDay, StartDate: startDate.Format("Mon, 02 Jan 2006 15:04:05 GMT"), Athletics: &athletics,
+		AttendanceMode: model.LegacyEventAttendanceInPerson}
+	setLegacyEventDescription(&event)
+
+	item := model.LegacyEventItem{SyncProcessSource: sidearmSyncProcessSource, SyncDate: now,
+		Status: model.LegacyEventStatus{Name: model.LegacyEventStatusValid}, Item: event}
+	setLegacyEventQueryFields(&item)
+	return item
+}
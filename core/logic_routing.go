@@ -0,0 +1,438 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/utils"
+	"container/heap"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//walkingSpeed is the walking speed in meters per second used for the routes durations
+	walkingSpeed = 1.4
+	//walkingMaxSnapDistance is how far in meters the route origin and destination may be from the walking paths
+	walkingMaxSnapDistance = 300
+	//walkingTurnAngle is the smallest change of direction in degrees that starts a new route step
+	walkingTurnAngle = 40
+)
+
+type routingLogic struct {
+	app    *Application
+	logger logs.Logger
+
+	walkingPaths WalkingPaths
+
+	//loaded on start, read only after it
+	graph *walkingGraph
+}
+
+func (r routingLogic) start() error {
+	if r.walkingPaths == nil {
+		r.logger.Info("walking paths are not configured, the walking directions are not available")
+		return nil
+	}
+
+	paths, err := r.walkingPaths.LoadPaths()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeWalkingPath, nil, err)
+	}
+	*r.graph = *newWalkingGraph(paths)
+	if len(paths) == 0 {
+		//the shipped extract is empty, every directions request would not find a route
+		r.logger.Warn("WALKING PATHS ARE EMPTY - the walking directions are not available until GATEWAY_WALKING_PATHS_FILE gives a campus extract")
+		return nil
+	}
+	r.logger.Infof("loaded %d walking paths with %d nodes", len(paths), len(r.graph.nodes))
+	return nil
+}
+
+// walkingTarget is a possible end of a route, one of the building entrances or the requested point
+type walkingTarget struct {
+	latitude  float64
+	longitude float64
+	entrance  *model.Entrance
+}
+
+// findWalkingRoute gives the shortest walking route from the origin to the closest reachable target, nil when there is none.
+// The targets are the building entrances when the building is set
+func (r routingLogic) findWalkingRoute(latitude float64, longitude float64, building *model.Building, targets []walkingTarget, accessibleOnly bool) *model.WalkingRoute {
+	origin := r.graph.snap(latitude, longitude, accessibleOnly)
+	if origin == nil {
+		return nil
+	}
+	targetSnaps := make([]*walkingSnap, len(targets))
+	for i, target := range targets {
+		targetSnaps[i] = r.graph.snap(target.latitude, target.longitude, accessibleOnly)
+	}
+
+	segments, reached := r.graph.shortestPath(*origin, targetSnaps, accessibleOnly)
+	if reached < 0 {
+		return nil
+	}
+	target := targets[reached]
+
+	//walk from the origin to the paths and from the paths to the target
+	segments[0].coordinates = append([][]float64{{longitude, latitude}}, segments[0].coordinates...)
+	segments[0].length += origin.distance
+	last := &segments[len(segments)-1]
+	last.coordinates = append(last.coordinates, []float64{target.longitude, target.latitude})
+	last.length += targetSnaps[reached].distance
+
+	route := model.WalkingRoute{Accessible: accessibleOnly, Building: building, Entrance: target.entrance}
+	for _, segment := range segments {
+		route.Distance += segment.length
+		route.Coordinates = appendCoordinates(route.Coordinates, segment.coordinates)
+	}
+	route.Duration = route.Distance / walkingSpeed
+
+	route.Steps = walkingSteps(segments, r.graph.paths)
+	lastStep := route.Steps[len(route.Steps)-1]
+	arrival := model.WalkingRouteStep{Instruction: arrivalInstruction(building, target.entrance), Bearing: lastStep.Bearing,
+		Coordinates: [][]float64{route.Coordinates[len(route.Coordinates)-1]}}
+	route.Steps = append(route.Steps, arrival)
+	return &route
+}
+
+// walkingNode is a point of the walking paths
+type walkingNode struct {
+	latitude  float64
+	longitude float64
+}
+
+// walkingEdge connects two nodes of the walking paths in both directions
+type walkingEdge struct {
+	to     int
+	length float64
+	path   int //the index of the walking path it belongs to
+}
+
+// walkingGraph is the pedestrian network built from the walking paths, the paths sharing a point are connected there
+type walkingGraph struct {
+	nodes []walkingNode
+	edges [][]walkingEdge //by node index
+	paths []model.WalkingPath
+}
+
+func newWalkingGraph(paths []model.WalkingPath) *walkingGraph {
+	graph := walkingGraph{paths: paths}
+	nodeIndexes := map[[2]int64]int{}
+	nodeIndex := func(position []float64) int {
+		//about 1cm precision, enough to join the paths sharing a point
+		key := [2]int64{int64(math.Round(position[1] * 1e7)), int64(math.Round(position[0] * 1e7))}
+		if index, ok := nodeIndexes[key]; ok {
+			return index
+		}
+		index := len(graph.nodes)
+		nodeIndexes[key] = index
+		graph.nodes = append(graph.nodes, walkingNode{latitude: position[1], longitude: position[0]})
+		graph.edges = append(graph.edges, nil)
+		return index
+	}
+
+	for p, path := range paths {
+		from := nodeIndex(path.Coordinates[0])
+		for _, position := range path.Coordinates[1:] {
+			to := nodeIndex(position)
+			if to == from {
+				continue
+			}
+			length := utils.GreatCircleDistance(graph.nodes[from].latitude, graph.nodes[from].longitude, graph.nodes[to].latitude, graph.nodes[to].longitude)
+			graph.edges[from] = append(graph.edges[from], walkingEdge{to: to, length: length, path: p})
+			graph.edges[to] = append(graph.edges[to], walkingEdge{to: from, length: length, path: p})
+			from = to
+		}
+	}
+	return &graph
+}
+
+// walkingSnap is the point of the walking paths closest to a requested point
+type walkingSnap struct {
+	from     int
+	to       int
+	path     int
+	fraction float64 //where the point is between the edge nodes, from 0 at the from node to 1 at the to node
+	length   float64 //the edge length
+	node     walkingNode
+	distance float64 //from the requested point
+}
+
+// snap gives the closest point of the walking paths, nil when all the paths are too far
+func (g walkingGraph) snap(latitude float64, longitude float64, accessibleOnly bool) *walkingSnap {
+	//project the edges on a plane tangent at the requested point, the distances on the campus are short enough
+	metersPerDegree := utils.EarthRadius * math.Pi / 180
+	project := func(n walkingNode) (float64, float64) {
+		return (n.longitude - longitude) * metersPerDegree * math.Cos(latitude*math.Pi/180), (n.latitude - latitude) * metersPerDegree
+	}
+
+	var closest *walkingSnap
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			if edge.to < from || (accessibleOnly && g.paths[edge.path].Stairs) {
+				continue
+			}
+			ax, ay := project(g.nodes[from])
+			bx, by := project(g.nodes[edge.to])
+			dx, dy := bx-ax, by-ay
+			fraction := 0.0
+			if lengthSquared := dx*dx + dy*dy; lengthSquared > 0 {
+				fraction = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSquared))
+			}
+			x, y := ax+fraction*dx, ay+fraction*dy
+			distance := math.Hypot(x, y)
+			if distance > walkingMaxSnapDistance || (closest != nil && distance >= closest.distance) {
+				continue
+			}
+
+			node := walkingNode{latitude: latitude + y/metersPerDegree, longitude: longitude + x/(metersPerDegree*math.Cos(latitude*math.Pi/180))}
+			closest = &walkingSnap{from: from, to: edge.to, path: edge.path, fraction: fraction, length: edge.length, node: node, distance: distance}
+		}
+	}
+	return closest
+}
+
+// walkingSegment is a walked part of an edge
+type walkingSegment struct {
+	coordinates [][]float64 //longitude, latitude pairs
+	length      float64
+	path        int
+}
+
+// shortestPath finds the shortest path from the origin to the closest of the targets by A*.
+// It gives the walked segments and the index of the reached target, -1 when none of them can be reached
+func (g walkingGraph) shortestPath(origin walkingSnap, targets []*walkingSnap, accessibleOnly bool) ([]walkingSegment, int) {
+	//the origin and the targets are added as virtual nodes connected to the ends of their edges
+	originIndex := len(g.nodes)
+	nodes := map[int]walkingNode{originIndex: origin.node}
+	extraEdges := map[int][]walkingEdge{
+		originIndex: {{to: origin.from, length: origin.fraction * origin.length, path: origin.path},
+			{to: origin.to, length: (1 - origin.fraction) * origin.length, path: origin.path}},
+	}
+	targetIndexes := map[int]int{}
+	for i, target := range targets {
+		if target == nil {
+			continue
+		}
+		index := originIndex + 1 + i
+		nodes[index] = target.node
+		targetIndexes[index] = i
+		extraEdges[target.from] = append(extraEdges[target.from], walkingEdge{to: index, length: target.fraction * target.length, path: target.path})
+		extraEdges[target.to] = append(extraEdges[target.to], walkingEdge{to: index, length: (1 - target.fraction) * target.length, path: target.path})
+		if target.from == origin.from && target.to == origin.to && target.path == origin.path {
+			//the origin and the target are on the same edge
+			extraEdges[originIndex] = append(extraEdges[originIndex], walkingEdge{to: index, length: math.Abs(target.fraction-origin.fraction) * origin.length, path: origin.path})
+		}
+	}
+	if len(targetIndexes) == 0 {
+		return nil, -1
+	}
+
+	node := func(index int) walkingNode {
+		if index < len(g.nodes) {
+			return g.nodes[index]
+		}
+		return nodes[index]
+	}
+	heuristic := func(index int) float64 {
+		n := node(index)
+		closest := math.Inf(1)
+		for targetIndex := range targetIndexes {
+			t := nodes[targetIndex]
+			closest = math.Min(closest, utils.GreatCircleDistance(n.latitude, n.longitude, t.latitude, t.longitude))
+		}
+		return closest
+	}
+
+	distances := map[int]float64{originIndex: 0}
+	previous := map[int]walkingEdge{} //the edge reaching the node with "to" set to the previous node
+	queue := &walkingQueue{{node: originIndex, priority: heuristic(originIndex)}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(walkingQueueItem)
+		if current.priority > distances[current.node]+heuristic(current.node)+1e-9 {
+			//outdated queue item
+			continue
+		}
+		if target, ok := targetIndexes[current.node]; ok {
+			return g.segments(current.node, originIndex, previous, node), target
+		}
+
+		edges := extraEdges[current.node]
+		if current.node < len(g.nodes) {
+			edges = append(slices.Clip(g.edges[current.node]), edges...)
+		}
+		for _, edge := range edges {
+			if accessibleOnly && g.paths[edge.path].Stairs {
+				continue
+			}
+			distance := distances[current.node] + edge.length
+			if known, ok := distances[edge.to]; ok && known <= distance {
+				continue
+			}
+			distances[edge.to] = distance
+			previous[edge.to] = walkingEdge{to: current.node, length: edge.length, path: edge.path}
+			heap.Push(queue, walkingQueueItem{node: edge.to, priority: distance + heuristic(edge.to)})
+		}
+	}
+	return nil, -1
+}
+
+// segments gives the segments of the found path from the origin to the target
+func (g walkingGraph) segments(target int, origin int, previous map[int]walkingEdge, node func(int) walkingNode) []walkingSegment {
+	segments := []walkingSegment{}
+	for current := target; current != origin; {
+		edge := previous[current]
+		from, to := node(edge.to), node(current)
+		segments = append(segments, walkingSegment{coordinates: [][]float64{{from.longitude, from.latitude}, {to.longitude, to.latitude}}, length: edge.length, path: edge.path})
+		current = edge.to
+	}
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	if len(segments) == 0 {
+		//the origin is the target
+		n := node(origin)
+		segments = append(segments, walkingSegment{coordinates: [][]float64{{n.longitude, n.latitude}}})
+	}
+	return segments
+}
+
+// walkingQueueItem is a node waiting in the A* priority queue
+type walkingQueueItem struct {
+	node     int
+	priority float64
+}
+
+// walkingQueue is the A* priority queue, the lowest priority comes first
+type walkingQueue []walkingQueueItem
+
+func (q walkingQueue) Len() int           { return len(q) }
+func (q walkingQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q walkingQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *walkingQueue) Push(x any) { *q = append(*q, x.(walkingQueueItem)) }
+
+func (q *walkingQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// walkingSteps groups the segments walked on the same path in the same direction into the route steps
+func walkingSteps(segments []walkingSegment, paths []model.WalkingPath) []model.WalkingRouteStep {
+	steps := []model.WalkingRouteStep{}
+	var current *model.WalkingRouteStep
+	var lastBearing float64
+	for _, segment := range segments {
+		path := paths[segment.path]
+		bearing := lastBearing
+		if len(segment.coordinates) > 1 {
+			start, end := segment.coordinates[0], segment.coordinates[len(segment.coordinates)-1]
+			bearing = utils.InitialBearing(start[1], start[0], end[1], end[0])
+		}
+
+		turn := math.Mod(bearing-lastBearing+540, 360) - 180
+		if current == nil || current.Name != path.Name || current.Stairs != path.Stairs || math.Abs(turn) >= walkingTurnAngle {
+			instruction := walkingInstruction(current == nil, bearing, turn, path)
+			steps = append(steps, model.WalkingRouteStep{Instruction: instruction, Name: path.Name, Bearing: bearing, Stairs: path.Stairs})
+			current = &steps[len(steps)-1]
+		}
+		current.Distance += segment.length
+		current.Coordinates = appendCoordinates(current.Coordinates, segment.coordinates)
+		lastBearing = bearing
+	}
+	return steps
+}
+
+// walkingInstruction gives the instruction at the start of a step, like "Head north on Green Street" or "Turn left"
+func walkingInstruction(first bool, bearing float64, turn float64, path model.WalkingPath) string {
+	var instruction string
+	switch {
+	case first:
+		instruction = "Head " + cardinalDirection(bearing)
+	case math.Abs(turn) < 20:
+		instruction = "Continue"
+	default:
+		side := "right"
+		if turn < 0 {
+			side = "left"
+		}
+		switch {
+		case math.Abs(turn) < 45:
+			instruction = "Turn slightly " + side
+		case math.Abs(turn) < 135:
+			instruction = "Turn " + side
+		default:
+			instruction = "Turn sharply " + side
+		}
+	}
+
+	if path.Stairs {
+		if instruction == "Continue" {
+			return "Take the stairs"
+		}
+		return instruction + " and take the stairs"
+	}
+	if len(path.Name) > 0 {
+		if first {
+			return instruction + " on " + path.Name
+		}
+		return instruction + " onto " + path.Name
+	}
+	return instruction
+}
+
+// cardinalDirection gives the name of the direction closest to the bearing
+func cardinalDirection(bearing float64) string {
+	directions := []string{"north", "northeast", "east", "southeast", "south", "southwest", "west", "northwest"}
+	return directions[int(math.Mod(bearing+22.5, 360)/45)%len(directions)]
+}
+
+// appendCoordinates appends the coordinates skipping the first one when it repeats the last appended one
+func appendCoordinates(coordinates [][]float64, other [][]float64) [][]float64 {
+	if len(coordinates) > 0 && len(other) > 0 {
+		last, first := coordinates[len(coordinates)-1], other[0]
+		if last[0] == first[0] && last[1] == first[1] {
+			other = other[1:]
+		}
+	}
+	return append(coordinates, other...)
+}
+
+// arrivalInstruction gives the instruction of the last route step
+func arrivalInstruction(building *model.Building, entrance *model.Entrance) string {
+	names := []string{}
+	if entrance != nil && len(entrance.Name) > 0 {
+		names = append(names, entrance.Name)
+	}
+	if building != nil && len(building.Name) > 0 {
+		names = append(names, building.Name)
+	}
+	if len(names) == 0 {
+		return "Arrive at the destination"
+	}
+	return "Arrive at " + strings.Join(names, ", ")
+}
+
+func newRoutingLogic(app *Application, walkingPaths WalkingPaths, logger logs.Logger) routingLogic {
+	return routingLogic{app: app, walkingPaths: walkingPaths, graph: &walkingGraph{}, logger: logger}
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"math"
+	"testing"
+)
+
+// testWalkingPaths is a small network: Green Street from A through the junction J to B, stairs from J up to C
+// and an accessible ramp from A through D to C, plus an island path not connected to the others
+func testWalkingPaths() []model.WalkingPath {
+	return []model.WalkingPath{
+		{ID: "green", Name: "Green Street", Coordinates: [][]float64{{-88.230, 40.110}, {-88.228, 40.110}, {-88.226, 40.110}}},
+		{ID: "stairs", Coordinates: [][]float64{{-88.228, 40.110}, {-88.228, 40.111}}, Stairs: true},
+		{ID: "ramp", Name: "Quad Ramp", Coordinates: [][]float64{{-88.230, 40.110}, {-88.230, 40.111}, {-88.228, 40.111}}},
+		{ID: "island", Coordinates: [][]float64{{-88.220, 40.110}, {-88.220, 40.1105}}},
+	}
+}
+
+func TestNewWalkingGraph(t *testing.T) {
+	graph := newWalkingGraph(testWalkingPaths())
+
+	if len(graph.nodes) != 7 {
+		t.Errorf("nodes count = %d, want 7", len(graph.nodes))
+	}
+	//the junction J is shared by Green Street and the stairs
+	if edges := graph.edges[1]; len(edges) != 3 {
+		t.Errorf("junction edges count = %d, want 3", len(edges))
+	}
+}
+
+func TestWalkingGraphSnap(t *testing.T) {
+	graph := newWalkingGraph(testWalkingPaths())
+
+	tests := []struct {
+		name           string
+		latitude       float64
+		longitude      float64
+		accessibleOnly bool
+		wantNil        bool
+		wantPath       string
+		wantDistance   float64
+		wantFraction   float64
+	}{
+		{name: "closest is the stairs", latitude: 40.1106, longitude: -88.2281, wantPath: "stairs", wantDistance: 8.5, wantFraction: 0.6},
+		{name: "stairs skipped in accessible mode", latitude: 40.1106, longitude: -88.2281, accessibleOnly: true, wantPath: "ramp", wantDistance: 44.5, wantFraction: 0.05},
+		{name: "between the edge nodes", latitude: 40.1101, longitude: -88.229, wantPath: "green", wantDistance: 11.1, wantFraction: 0.5},
+		{name: "too far from the paths", latitude: 40.110, longitude: -88.240, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := graph.snap(tt.latitude, tt.longitude, tt.accessibleOnly)
+			if tt.wantNil {
+				if snap != nil {
+					t.Errorf("snap() = %+v, want nil", snap)
+				}
+				return
+			}
+			if snap == nil {
+				t.Fatal("snap() = nil")
+			}
+			if path := graph.paths[snap.path].ID; path != tt.wantPath {
+				t.Errorf("snap() path = %s, want %s", path, tt.wantPath)
+			}
+			if math.Abs(snap.distance-tt.wantDistance) > 0.5 {
+				t.Errorf("snap() distance = %f, want %f", snap.distance, tt.wantDistance)
+			}
+			if math.Abs(snap.fraction-tt.wantFraction) > 0.01 {
+				t.Errorf("snap() fraction = %f, want %f", snap.fraction, tt.wantFraction)
+			}
+		})
+	}
+}
+
+func TestFindWalkingRoute(t *testing.T) {
+	graph := newWalkingGraph(testWalkingPaths())
+	r := routingLogic{graph: graph}
+
+	tests := []struct {
+		name           string
+		latitude       float64
+		longitude      float64
+		target         walkingTarget
+		accessibleOnly bool
+		wantNil        bool
+		wantDistance   float64
+		wantStairs     bool
+	}{
+		{name: "up the stairs", latitude: 40.1099, longitude: -88.2260, target: walkingTarget{latitude: 40.1110, longitude: -88.2281},
+			wantDistance: 300.9, wantStairs: true},
+		{name: "around the stairs in accessible mode", latitude: 40.1099, longitude: -88.2260, target: walkingTarget{latitude: 40.1110, longitude: -88.2281},
+			accessibleOnly: true, wantDistance: 624.0},
+		{name: "origin and target on the same edge", latitude: 40.1101, longitude: -88.2295, target: walkingTarget{latitude: 40.1101, longitude: -88.2285},
+			wantDistance: 107.3},
+		{name: "target not connected", latitude: 40.1099, longitude: -88.2260, target: walkingTarget{latitude: 40.1102, longitude: -88.2201},
+			wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := r.findWalkingRoute(tt.latitude, tt.longitude, nil, []walkingTarget{tt.target}, tt.accessibleOnly)
+			if tt.wantNil {
+				if route != nil {
+					t.Errorf("findWalkingRoute() = %+v, want nil", route)
+				}
+				return
+			}
+			if route == nil {
+				t.Fatal("findWalkingRoute() = nil")
+			}
+			if math.Abs(route.Distance-tt.wantDistance) > 1 {
+				t.Errorf("findWalkingRoute() distance = %f, want %f", route.Distance, tt.wantDistance)
+			}
+			stairs := false
+			for _, step := range route.Steps {
+				stairs = stairs || step.Stairs
+			}
+			if stairs != tt.wantStairs {
+				t.Errorf("findWalkingRoute() stairs = %t, want %t", stairs, tt.wantStairs)
+			}
+			first, last := route.Coordinates[0], route.Coordinates[len(route.Coordinates)-1]
+			if first[0] != tt.longitude || first[1] != tt.latitude || last[0] != tt.target.longitude || last[1] != tt.target.latitude {
+				t.Errorf("findWalkingRoute() coordinates from %v to %v, want the origin and the target", first, last)
+			}
+		})
+	}
+}
@@ -15,12 +15,16 @@
 package model
 
 import (
+	"time"
+
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 const (
 	//TypeAppBuildingFeature type
 	TypeAppBuildingFeature logutils.MessageDataType = "application building feature"
+	//TypeManagedFeatureLocation type
+	TypeManagedFeatureLocation logutils.MessageDataType = "managed feature location"
 )
 
 // AppBuildingFeature represents the configured features for campus buildings
@@ -31,3 +35,21 @@ type AppBuildingFeature struct {
 	AppCode    string `json:"app_code" bson:"app_code"`
 	ShowInApp  bool   `json:"show_in_app" bson:"show_in_app"`
 }
+
+// ManagedFeatureLocation represents an admin managed location of a feature in a building. It replaces the location
+// given by the wayfinding data for the same feature key or adds the feature to the building, no floors hide the feature
+type ManagedFeatureLocation struct {
+	ID             string     `json:"id" bson:"_id"`
+	BuildingNumber string     `json:"building_number" bson:"building_number"`
+	Key            string     `json:"key" bson:"key"` //the app code of the catalog entry, or the campus code of the features missing in the catalog
+	Name           string     `json:"name" bson:"name"`
+	Floors         []string   `json:"floors" bson:"floors"`
+	DateCreated    time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated    *time.Time `json:"date_updated" bson:"date_updated"`
+}
+
+// BuildingFeatureSearchResult represents a building which has the searched feature
+type BuildingFeatureSearchResult struct {
+	Building Building
+	Feature  BuildingFeatureLocation
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// SidearmGame represents a game of the Sidearm athletics schedule feed
+type SidearmGame struct {
+	ID        string
+	Sport     string
+	Opponent  string
+	HomeAway  string //home, away or neutral
+	Venue     string
+	City      string
+	StartDate time.Time
+	AllDay    bool //the game time is to be announced
+	TicketURL string
+	GameURL   string
+}
@@ -15,12 +15,16 @@
 package model
 
 import (
+	"time"
+
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 const (
 	//TypeBuilding type
 	TypeBuilding logutils.MessageDataType = "building"
+	//TypeBuildingAlias type
+	TypeBuildingAlias logutils.MessageDataType = "building alias"
 )
 
 // Entrance represents the information returned when the closest entrance of a building is requested
@@ -55,6 +59,36 @@ type Building struct {
 	Features    []BuildingFeatureLocation
 }
 
+// BuildingAlias represents an admin managed nickname of a building like "DCL" or "the Union" used by the buildings search and the event locations
+type BuildingAlias struct {
+	ID          string    `json:"id" bson:"_id"`
+	Alias       string    `json:"alias" bson:"alias"`
+	BuildingID  string    `json:"building_id" bson:"building_id"` //the building id, or its number when it has no id
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+}
+
+// BuildingSearchResult represents a building found by the buildings search with its relevance
+type BuildingSearchResult struct {
+	Building     Building
+	Score        float64 //from 0 to 1, 1 for an exact match
+	MatchedField string  //name, short_name, number, address or alias
+}
+
+// NearbyBuilding represents a building found near a point
+type NearbyBuilding struct {
+	Building
+	NearestEntrance *Entrance `json:"nearest_entrance"` //nil when the building has no entrances
+	Distance        float64   `json:"distance"`         //in meters, to the nearest entrance or to the building center
+}
+
+// NearestEntrance represents the entrance of a building closest to the user
+type NearestEntrance struct {
+	Entrance
+	Distance *float64 `json:"distance"` //in meters, nil when the user's position is unknown
+	Bearing  *float64 `json:"bearing"`  //in degrees clockwise from the north, nil when the user's position is unknown
+	Primary  bool     `json:"primary"`  //true when the building's configured primary entrance is returned
+}
+
 // CompactBuilding represents minimal building informaiton needed to display a builgins details on the details panel
 type CompactBuilding struct {
 	ID          string
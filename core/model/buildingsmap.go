@@ -0,0 +1,121 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeBuildingFootprint type
+	TypeBuildingFootprint logutils.MessageDataType = "building footprint"
+)
+
+// BoundingBox represents the area of a map view
+type BoundingBox struct {
+	MinLongitude float64
+	MinLatitude  float64
+	MaxLongitude float64
+	MaxLatitude  float64
+}
+
+// Contains tells if the point is in the box
+func (b BoundingBox) Contains(latitude float64, longitude float64) bool {
+	return latitude >= b.MinLatitude && latitude <= b.MaxLatitude && longitude >= b.MinLongitude && longitude <= b.MaxLongitude
+}
+
+// Intersects tells if the boxes overlap
+func (b BoundingBox) Intersects(other BoundingBox) bool {
+	return other.MinLatitude <= b.MaxLatitude && other.MaxLatitude >= b.MinLatitude && other.MinLongitude <= b.MaxLongitude && other.MaxLongitude >= b.MinLongitude
+}
+
+// ParseBoundingBox parses a "minLongitude,minLatitude,maxLongitude,maxLatitude" box like the GeoJSON bbox, nil for an empty value
+func ParseBoundingBox(value string) (*BoundingBox, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid bounding box %q", value)
+	}
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bounding box coordinate %q", part)
+		}
+		values[i] = number
+	}
+
+	box := BoundingBox{MinLongitude: values[0], MinLatitude: values[1], MaxLongitude: values[2], MaxLatitude: values[3]}
+	if box.MinLongitude < -180 || box.MaxLongitude > 180 || box.MinLatitude < -90 || box.MaxLatitude > 90 ||
+		box.MinLongitude > box.MaxLongitude || box.MinLatitude > box.MaxLatitude {
+		return nil, fmt.Errorf("invalid bounding box %q", value)
+	}
+	return &box, nil
+}
+
+// BuildingFootprint represents the outline of a building
+type BuildingFootprint struct {
+	BuildingNumber string
+	Polygons       [][][][]float64 //the MultiPolygon coordinates, longitude and latitude pairs
+}
+
+// BoundingBox gives the smallest box containing the footprint
+func (f BuildingFootprint) BoundingBox() BoundingBox {
+	box := BoundingBox{MinLongitude: 180, MinLatitude: 90, MaxLongitude: -180, MaxLatitude: -90}
+	for _, polygon := range f.Polygons {
+		for _, ring := range polygon {
+			for _, position := range ring {
+				box.MinLongitude, box.MaxLongitude = min(box.MinLongitude, position[0]), max(box.MaxLongitude, position[0])
+				box.MinLatitude, box.MaxLatitude = min(box.MinLatitude, position[1]), max(box.MaxLatitude, position[1])
+			}
+		}
+	}
+	return box
+}
+
+// MapBuilding represents a building of the map layers, drawn by its footprint when it is known
+type MapBuilding struct {
+	Building
+	Footprint *BuildingFootprint //nil when the footprint is unknown, the building is a point then
+}
+
+// MapEntrance represents a building entrance of the map layers
+type MapEntrance struct {
+	Entrance
+	BuildingID     string
+	BuildingNumber string
+	BuildingName   string
+}
+
+// MapFeature represents a feature found in a building, like an elevator or an AED, of the map layers. It is at the building center
+type MapFeature struct {
+	Key            string
+	Name           string
+	CampusCode     string //the code of the feature catalog entry, empty when the feature is not in the catalog
+	Floors         []string
+	BuildingID     string
+	BuildingNumber string
+	BuildingName   string
+	Latitude       float64
+	Longitude      float64
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBoundingBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *BoundingBox
+		wantErr bool
+	}{
+		{name: "campus", value: "-88.24,40.09,-88.21,40.12", want: &BoundingBox{MinLongitude: -88.24, MinLatitude: 40.09, MaxLongitude: -88.21, MaxLatitude: 40.12}},
+		{name: "spaces", value: " -88.24, 40.09 ,-88.21,40.12 ", want: &BoundingBox{MinLongitude: -88.24, MinLatitude: 40.09, MaxLongitude: -88.21, MaxLatitude: 40.12}},
+		{name: "a point", value: "-88.22,40.11,-88.22,40.11", want: &BoundingBox{MinLongitude: -88.22, MinLatitude: 40.11, MaxLongitude: -88.22, MaxLatitude: 40.11}},
+		{name: "empty", value: "", want: nil},
+		{name: "three values", value: "-88.24,40.09,-88.21", wantErr: true},
+		{name: "not a number", value: "-88.24,north,-88.21,40.12", wantErr: true},
+		{name: "swapped longitudes", value: "-88.21,40.09,-88.24,40.12", wantErr: true},
+		{name: "swapped latitudes", value: "-88.24,40.12,-88.21,40.09", wantErr: true},
+		{name: "latitude out of range", value: "-88.24,40.09,-88.21,91", wantErr: true},
+		{name: "longitude out of range", value: "-181,40.09,-88.21,40.12", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBoundingBox(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBoundingBox() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBoundingBox() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+	box := BoundingBox{MinLongitude: -88.24, MinLatitude: 40.09, MaxLongitude: -88.21, MaxLatitude: 40.12}
+
+	tests := []struct {
+		name  string
+		other BoundingBox
+		want  bool
+	}{
+		{name: "inside", other: BoundingBox{MinLongitude: -88.23, MinLatitude: 40.10, MaxLongitude: -88.22, MaxLatitude: 40.11}, want: true},
+		{name: "containing", other: BoundingBox{MinLongitude: -89, MinLatitude: 40, MaxLongitude: -88, MaxLatitude: 41}, want: true},
+		{name: "overlapping a corner", other: BoundingBox{MinLongitude: -88.22, MinLatitude: 40.11, MaxLongitude: -88.20, MaxLatitude: 40.13}, want: true},
+		{name: "touching an edge", other: BoundingBox{MinLongitude: -88.21, MinLatitude: 40.10, MaxLongitude: -88.20, MaxLatitude: 40.11}, want: true},
+		{name: "east of it", other: BoundingBox{MinLongitude: -88.20, MinLatitude: 40.10, MaxLongitude: -88.19, MaxLatitude: 40.11}, want: false},
+		{name: "north of it", other: BoundingBox{MinLongitude: -88.23, MinLatitude: 40.13, MaxLongitude: -88.22, MaxLatitude: 40.14}, want: false},
+		{name: "same longitudes south of it", other: BoundingBox{MinLongitude: -88.24, MinLatitude: 40.00, MaxLongitude: -88.21, MaxLatitude: 40.05}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := box.Intersects(tt.other); got != tt.want {
+				t.Errorf("Intersects() = %t, want %t", got, tt.want)
+			}
+			if got := tt.other.Intersects(box); got != tt.want {
+				t.Errorf("reversed Intersects() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
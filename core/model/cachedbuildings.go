@@ -23,10 +23,38 @@ import (
 const (
 	//TypeCachedBuildings type
 	TypeCachedBuildings logutils.MessageDataType = "cached buildings"
+	//TypeBuildingsDataset type
+	TypeBuildingsDataset logutils.MessageDataType = "buildings dataset"
 )
 
-// CachedBuildings holds the building information from the building adapter
-type CachedBuildings struct {
-	Buildings []Building `json:"buildings" bson:"buildings"`
-	LoadDate  time.Time  `json:"load_date" bson:"load_date"`
+// BuildingsDataset is a versioned snapshot of the campus buildings loaded from the building adapter
+type BuildingsDataset struct {
+	ID        string                  `json:"id" bson:"_id"`
+	Version   int                     `json:"version" bson:"version"`
+	Buildings []Building              `json:"buildings" bson:"buildings"`
+	Changes   BuildingsDatasetChanges `json:"changes" bson:"changes"` //the changes from the previous version
+
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+	DateChecked time.Time `json:"date_checked" bson:"date_checked"` //the last time the building adapter returned the same buildings
+}
+
+// BuildingsDatasetChanges holds the differences between two versions of the buildings dataset
+type BuildingsDatasetChanges struct {
+	Added            []string         `json:"added" bson:"added"`                         //the ids of the added buildings
+	Removed          []string         `json:"removed" bson:"removed"`                     //the ids of the removed buildings
+	Renamed          []BuildingRename `json:"renamed" bson:"renamed"`                     //the buildings which have got a new name
+	EntrancesChanged []string         `json:"entrances_changed" bson:"entrances_changed"` //the ids of the buildings with added, removed or modified entrances
+	Updated          []string         `json:"updated" bson:"updated"`                     //the ids of the buildings with any other modified details
+}
+
+// BuildingRename represents a building name change
+type BuildingRename struct {
+	ID      string `json:"id" bson:"id"`
+	OldName string `json:"old_name" bson:"old_name"`
+	NewName string `json:"new_name" bson:"new_name"`
+}
+
+// IsEmpty tells if there are no changes
+func (c BuildingsDatasetChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Renamed) == 0 && len(c.EntrancesChanged) == 0 && len(c.Updated) == 0
 }
@@ -31,6 +31,10 @@ const (
 
 	// ConfigTypeEnv is the Config Type for EnvConfigData
 	ConfigTypeEnv string = "env"
+	// ConfigTypeTPSModeration is the Config Type for TPSModerationConfigData
+	ConfigTypeTPSModeration string = "tps_moderation"
+	// ConfigTypeBuildingEntrances is the Config Type for BuildingEntrancesConfigData
+	ConfigTypeBuildingEntrances string = "building_entrances"
 )
 
 // Config contain generic configs
@@ -62,6 +66,22 @@ type EnvConfigData struct {
 	PCPEndpoint             string `json:"GATEWAY_STUDENTSUCCESS_PCPENDPOINT" bson:"GATEWAY_STUDENTSUCCESS_PCPENDPOINT"`
 	ImageEndpoint           string `json:"GATEWAY_STUDENTSUCCESS_IMAGES" bson:"GATEWAY_STUDENTSUCCESS_IMAGES"`
 	CrowdMeterURL           string `json:"GATEWAY_CROWDMETER_APIURL" bson:"GATEWAY_CROWDMETER_APIURL"`
+	SidearmFeedURL          string `json:"GATEWAY_SIDEARM_FEEDURL" bson:"GATEWAY_SIDEARM_FEEDURL"`
+}
+
+// TPSModerationConfigData contains the org and app third-party service accounts whose events are moderated
+type TPSModerationConfigData struct {
+	ModeratedAccountIDs []string `json:"moderated_account_ids" bson:"moderated_account_ids"`
+}
+
+// IsModerated tells if the events created by the account wait for moderation
+func (c TPSModerationConfigData) IsModerated(accountID string) bool {
+	for _, id := range c.ModeratedAccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
 }
 
 // GetConfigData returns a pointer to the given config's Data as the given type T
@@ -72,7 +92,17 @@ func GetConfigData[T ConfigData](c Config) (*T, error) {
 	return nil, errors.ErrorData(logutils.StatusInvalid, TypeConfigData, &logutils.FieldArgs{"type": c.Type})
 }
 
+// BuildingEntrancesConfigData contains the primary entrances of the buildings
+type BuildingEntrancesConfigData struct {
+	PrimaryEntrances map[string]string `json:"primary_entrances" bson:"primary_entrances"` //building number -> entrance id
+}
+
+// PrimaryEntrance gives the id of the building's primary entrance, empty when it is not configured
+func (c BuildingEntrancesConfigData) PrimaryEntrance(bldgID string) string {
+	return c.PrimaryEntrances[bldgID]
+}
+
 // ConfigData represents any set of data that may be stored in a config
 type ConfigData interface {
-	EnvConfigData | map[string]interface{}
+	EnvConfigData | TPSModerationConfigData | BuildingEntrancesConfigData | map[string]interface{}
 }
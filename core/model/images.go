@@ -14,10 +14,38 @@
 
 package model
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeImage type
+	TypeImage logutils.MessageDataType = "image"
+
+	//ImageRenditionThumbnail is the small rendition used by the list views
+	ImageRenditionThumbnail string = "thumbnail"
+	//ImageRenditionCard is the medium rendition used by the card views
+	ImageRenditionCard string = "card"
+	//ImageRenditionFull is the original size rendition used by the detail views
+	ImageRenditionFull string = "full"
+)
+
+// DefaultImageRenditions are the renditions generated when nothing is configured
+var DefaultImageRenditions = []ImageRendition{
+	{Name: ImageRenditionThumbnail, Width: 200, Height: 200, Quality: 80},
+	{Name: ImageRenditionCard, Width: 800, Height: 450, Quality: 90},
+	{Name: ImageRenditionFull, Width: 0, Height: 0, Quality: 100},
+}
+
 // ContentImagesURL is used to keep the imageURL from ContentBB
 type ContentImagesURL struct {
-	ID       string `json:"id" bson:"_id"`
-	ImageURL string `json:"imageURL" bson:"imageURL"`
+	ID       string            `json:"id" bson:"_id"`
+	ImageURL string            `json:"imageURL" bson:"imageURL"`
+	Images   map[string]string `json:"images" bson:"images"` //rendition name -> url
 }
 
 // ImageData is used to keep the the image thata from webtools
@@ -29,3 +57,68 @@ type ImageData struct {
 	Path      string `json:"path"`
 	FileName  string `json:"fileName"`
 }
+
+// ImageRendition represents one size in which an image is uploaded.
+// Width and height define the bounding box, 0 means the original size.
+type ImageRendition struct {
+	Name    string `json:"name"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Quality int    `json:"quality"`
+}
+
+// ParseImageRenditions parses renditions from "name:WIDTHxHEIGHT:QUALITY" entries separated by commas,
+// for example "thumbnail:200x200:80,card:800x450:90,full:0x0:100"
+func ParseImageRenditions(value string) ([]ImageRendition, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return DefaultImageRenditions, nil
+	}
+
+	res := []ImageRendition{}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid image rendition %q", entry)
+		}
+		size := strings.Split(strings.ToLower(parts[1]), "x")
+		if len(size) != 2 {
+			return nil, fmt.Errorf("invalid image rendition size %q", parts[1])
+		}
+		width, err := strconv.Atoi(size[0])
+		if err != nil || width < 0 {
+			return nil, fmt.Errorf("invalid image rendition width %q", size[0])
+		}
+		height, err := strconv.Atoi(size[1])
+		if err != nil || height < 0 {
+			return nil, fmt.Errorf("invalid image rendition height %q", size[1])
+		}
+		quality, err := strconv.Atoi(parts[2])
+		if err != nil || quality <= 0 || quality > 100 {
+			return nil, fmt.Errorf("invalid image rendition quality %q", parts[2])
+		}
+		res = append(res, ImageRendition{Name: parts[0], Width: width, Height: height, Quality: quality})
+	}
+	return res, nil
+}
+
+// Fit returns the size of an image with the given dimensions scaled down to fit the rendition box.
+// The aspect ratio is kept and the image is never scaled up.
+func (r ImageRendition) Fit(width int, height int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return width, height
+	}
+	scale := 1.0
+	if r.Width > 0 && width > r.Width {
+		scale = float64(r.Width) / float64(width)
+	}
+	if r.Height > 0 && float64(height)*scale > float64(r.Height) {
+		scale = float64(r.Height) / float64(height)
+	}
+	if scale >= 1.0 {
+		return width, height
+	}
+	fitWidth := max(int(float64(width)*scale), 1)
+	fitHeight := max(int(float64(height)*scale), 1)
+	return fitWidth, fitHeight
+}
@@ -16,16 +16,45 @@ package model
 
 import (
 	"encoding/xml"
+	"strconv"
 	"time"
 
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 const (
 	//TypeLegacyEvents type
 	TypeLegacyEvents logutils.MessageDataType = "legacy_events"
+	//TypeLegacyEventHistory type
+	TypeLegacyEventHistory logutils.MessageDataType = "legacy event history"
+	//TypeWebToolsSyncReport type
+	TypeWebToolsSyncReport logutils.MessageDataType = "webtools sync report"
+	//TypeLegacyEventsReconciliation type
+	TypeLegacyEventsReconciliation logutils.MessageDataType = "legacy events reconciliation"
+
+	//LegacyEventAttendanceInPerson the event takes place only in person
+	LegacyEventAttendanceInPerson string = "in-person"
+	//LegacyEventAttendanceHybrid the event takes place both in person and online
+	LegacyEventAttendanceHybrid string = "hybrid"
+	//LegacyEventAttendanceVirtual the event takes place only online
+	LegacyEventAttendanceVirtual string = "virtual"
 )
 
+// LegacyEventAttendanceModes are the supported legacy event attendance modes
+var LegacyEventAttendanceModes = []string{LegacyEventAttendanceInPerson, LegacyEventAttendanceHybrid, LegacyEventAttendanceVirtual}
+
+// NewLegacyEventAttendanceMode gives the attendance mode of an event which takes place in person and/or online
+func NewLegacyEventAttendanceMode(inPerson bool, virtual bool) string {
+	if virtual && inPerson {
+		return LegacyEventAttendanceHybrid
+	}
+	if virtual {
+		return LegacyEventAttendanceVirtual
+	}
+	return LegacyEventAttendanceInPerson
+}
+
 // WebToolsResponse represents web tools response item
 type WebToolsResponse struct {
 	XMLName        xml.Name        `xml:"responseWS"`
@@ -36,6 +65,46 @@ type WebToolsResponse struct {
 	WebToolsEvents []WebToolsEvent `xml:"publicEventWS"`
 }
 
+// WebToolsSyncReport represents the report of web tools events sync run
+type WebToolsSyncReport struct {
+	ID    string `json:"id" bson:"_id"` //the sync run id
+	OrgID string `json:"org_id" bson:"org_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+
+	Status      string   `json:"status" bson:"status"` //succeeded, incomplete or failed
+	PagesCount  int      `json:"pages_count" bson:"pages_count"`
+	FailedPages []int    `json:"failed_pages" bson:"failed_pages"`
+	EventsCount int      `json:"events_count" bson:"events_count"`
+	Warnings    []string `json:"warnings" bson:"warnings"` //the feed deprecation and end of service warnings
+	Error       string   `json:"error" bson:"error"`
+
+	CreatedCount int `json:"created_count" bson:"created_count"`
+	UpdatedCount int `json:"updated_count" bson:"updated_count"`
+	DeletedCount int `json:"deleted_count" bson:"deleted_count"`
+
+	DateStarted  time.Time `json:"date_started" bson:"date_started"`
+	DateFinished time.Time `json:"date_finished" bson:"date_finished"`
+}
+
+const (
+	//WebToolsSyncSucceeded the stored events have been replaced with the feed events
+	WebToolsSyncSucceeded string = "succeeded"
+	//WebToolsSyncIncomplete the feed has not been completely loaded so the stored events have not been changed
+	WebToolsSyncIncomplete string = "incomplete"
+	//WebToolsSyncFailed the stored events have not been changed because of an error
+	WebToolsSyncFailed string = "failed"
+)
+
+// AddWarning adds the warning once
+func (r *WebToolsSyncReport) AddWarning(warning string) {
+	for _, current := range r.Warnings {
+		if current == warning {
+			return
+		}
+	}
+	r.Warnings = append(r.Warnings, warning)
+}
+
 // WebToolsEvent represents web tools event entity
 type WebToolsEvent struct {
 	Text                               string `xml:",chardata"`
@@ -103,6 +172,12 @@ type WebToolsEvent struct {
 	} `xml:"topic"`
 }
 
+// WebToolsFeedRegistration represents the org and app the web tools feed events belong to
+type WebToolsFeedRegistration struct {
+	OrgID string
+	AppID string
+}
+
 // Blacklist represents web tools blacklist ids
 type Blacklist struct {
 	Name string   `json:"name" bson:"name"`
@@ -152,42 +227,89 @@ type Ignored struct {
 
 // LegacyEvent wrapper
 type LegacyEvent struct {
-	AllDay                  bool            `json:"allDay" bson:"allDay"`
-	CalendarID              string          `json:"calendarId" bson:"calendarId"`
-	Category                string          `json:"category" bson:"category"`
-	Subcategory             string          `json:"subcategory" bson:"subcategory"`
-	CreatedBy               string          `json:"createdBy" bson:"createdBy"`
-	LongDescription         string          `json:"longDescription" bson:"longDescription"`
-	DataModified            string          `json:"dataModified" bson:"dataModified"`
-	DataSourceEventID       string          `json:"dataSourceEventId" bson:"dataSourceEventId"`
-	DateCreated             string          `json:"dateCreated" bson:"dateCreated"`
-	EndDate                 string          `json:"endDate" bson:"endDate"`
-	EventID                 string          `json:"eventId" bson:"eventId"`
-	IcalURL                 string          `json:"icalUrl" bson:"icalUrl"`
-	ID                      string          `json:"id" bson:"id"`
-	ImageURL                *string         `json:"imageURL" bson:"imageURL"`
-	IsEventFree             bool            `json:"isEventFree" bson:"isEventFree"`
-	IsVirtial               bool            `json:"isVirtual" bson:"isVirtual"`
-	Location                *LocationLegacy `json:"location" bson:"location"`
-	OriginatingCalendarID   string          `json:"originatingCalendarId" bson:"originatingCalendarId"`
-	OriginatingCalendarName string          `json:"originatingCalendarName" bson:"originatingCalendarName"`
-	OutlookURL              string          `json:"outlookUrl" bson:"outlookUrl"`
-	RecurrenceID            *int            `json:"recurrenceId" bson:"recurrenceId"`
-	IsSuperEvent            bool            `json:"isSuperEvent" bson:"isSuperEvent"`
-	RecurringFlag           bool            `json:"recurringFlag" bson:"recurringFlag"`
-	SourceID                string          `json:"sourceId" bson:"sourceId"`
-	Sponsor                 string          `json:"sponsor" bson:"sponsor"`
-	StartDate               string          `json:"startDate" bson:"startDate"`
-	Title                   string          `json:"title" bson:"title"`
-	TitleURL                string          `json:"titleURL" bson:"titleURL"`
-	Tags                    *[]string       `json:"tags" bson:"tags"`
-	TargetAudience          *[]string       `json:"targetAudience" bson:"targetAudience"`
-	RegistrationURL         string          `json:"registrationURL" bson:"registrationURL"`
-	Contacts                []ContactLegacy `json:"contacts" bson:"contacts"`
-	SubEvents               []SubEvents     `json:"subEvents" bson:"subEvents"`
-	Cost                    string          `json:"cost" bson:"cost"`
+	AllDay                  bool                  `json:"allDay" bson:"allDay"`
+	CalendarID              string                `json:"calendarId" bson:"calendarId"`
+	Category                string                `json:"category" bson:"category"`
+	Subcategory             string                `json:"subcategory" bson:"subcategory"`
+	CreatedBy               string                `json:"createdBy" bson:"createdBy"`
+	LongDescription         string                `json:"longDescription" bson:"longDescription"` //sanitized html
+	DescriptionText         string                `json:"descriptionText" bson:"descriptionText"`
+	DescriptionMarkdown     string                `json:"descriptionMarkdown" bson:"descriptionMarkdown"`
+	DescriptionLinks        []DescriptionLink     `json:"descriptionLinks" bson:"descriptionLinks"`
+	DataModified            string                `json:"dataModified" bson:"dataModified"`
+	DataSourceEventID       string                `json:"dataSourceEventId" bson:"dataSourceEventId"`
+	DateCreated             string                `json:"dateCreated" bson:"dateCreated"`
+	EndDate                 string                `json:"endDate" bson:"endDate"`
+	EventID                 string                `json:"eventId" bson:"eventId"`
+	IcalURL                 string                `json:"icalUrl" bson:"icalUrl"`
+	ID                      string                `json:"id" bson:"id"`
+	ImageURL                *string               `json:"imageURL" bson:"imageURL"`
+	Images                  map[string]string     `json:"images" bson:"images"` //rendition name -> url
+	IsEventFree             bool                  `json:"isEventFree" bson:"isEventFree"`
+	IsVirtial               bool                  `json:"isVirtual" bson:"isVirtual"`
+	VirtualEventURL         string                `json:"virtualEventUrl" bson:"virtualEventUrl"`
+	AttendanceMode          string                `json:"attendanceMode" bson:"attendanceMode"` //in-person, hybrid or virtual
+	Location                *LocationLegacy       `json:"location" bson:"location"`
+	OriginatingCalendarID   string                `json:"originatingCalendarId" bson:"originatingCalendarId"`
+	OriginatingCalendarName string                `json:"originatingCalendarName" bson:"originatingCalendarName"`
+	OutlookURL              string                `json:"outlookUrl" bson:"outlookUrl"`
+	RecurrenceID            *int                  `json:"recurrenceId" bson:"recurrenceId"`
+	IsSuperEvent            bool                  `json:"isSuperEvent" bson:"isSuperEvent"`
+	RecurringFlag           bool                  `json:"recurringFlag" bson:"recurringFlag"`
+	SourceID                string                `json:"sourceId" bson:"sourceId"`
+	Sponsor                 string                `json:"sponsor" bson:"sponsor"`
+	Speaker                 string                `json:"speaker" bson:"speaker"`
+	StartDate               string                `json:"startDate" bson:"startDate"`
+	Title                   string                `json:"title" bson:"title"`
+	TitleURL                string                `json:"titleURL" bson:"titleURL"`
+	Tags                    *[]string             `json:"tags" bson:"tags"`
+	TargetAudience          *[]string             `json:"targetAudience" bson:"targetAudience"`
+	RegistrationURL         string                `json:"registrationURL" bson:"registrationURL"`
+	Contacts                []ContactLegacy       `json:"contacts" bson:"contacts"`
+	SubEvents               []SubEvents           `json:"subEvents" bson:"subEvents"`
+	Cost                    string                `json:"cost" bson:"cost"`
+	CostDetails             *LegacyEventCost      `json:"costDetails" bson:"costDetails"` //parsed from the cost
+	Athletics               *LegacyEventAthletics `json:"athletics" bson:"athletics"`     //only for the athletics events
+}
+
+// LegacyEventAthletics represents the athletics details of a game event
+type LegacyEventAthletics struct {
+	Sport     string `json:"sport" bson:"sport"`
+	Opponent  string `json:"opponent" bson:"opponent"`
+	HomeAway  string `json:"homeAway" bson:"homeAway"` //home, away or neutral
+	Venue     string `json:"venue" bson:"venue"`
+	TicketURL string `json:"ticketUrl" bson:"ticketUrl"`
+}
+
+const (
+	//LegacyEventAthleticsHome the game is played at home
+	LegacyEventAthleticsHome string = "home"
+	//LegacyEventAthleticsAway the game is played at the opponent
+	LegacyEventAthleticsAway string = "away"
+	//LegacyEventAthleticsNeutral the game is played at a neutral site
+	LegacyEventAthleticsNeutral string = "neutral"
+)
+
+// LegacyEventCost represents the structured cost of an event parsed from its cost text
+type LegacyEventCost struct {
+	Tiers  []LegacyEventCostTier `json:"tiers" bson:"tiers"`
+	Varies bool                  `json:"varies" bson:"varies"` //the cost varies or it is a donation
+}
+
+// LegacyEventCostTier represents the price of an event for an audience
+type LegacyEventCostTier struct {
+	Amount   float64 `json:"amount" bson:"amount"`
+	Currency string  `json:"currency" bson:"currency"`
+	Audience string  `json:"audience" bson:"audience"` //empty for everyone
 }
 
+const (
+	//LegacyEventCostAudienceStudents the price for the students
+	LegacyEventCostAudienceStudents string = "students"
+	//LegacyEventCostAudiencePublic the price for the general public
+	LegacyEventCostAudiencePublic string = "general public"
+)
+
 // LocationLegacy represents event legacy location
 type LocationLegacy struct {
 	Description string  `json:"description" bson:"description"`
@@ -195,8 +317,19 @@ type LocationLegacy struct {
 	Longitude   float64 `json:"longitude" bson:"longitude"`
 	Address     string  `json:"address" bson:"address"`
 	Building    string  `json:"building" bson:"building"`
-	Floor       int     `json:"floor" bson:"floor"`
+	Floor       int     `json:"floor" bson:"floor"` //0 when unknown, -1 for the basement
 	Room        string  `json:"room" bson:"room"`
+
+	//parsed from the description when the building is a campus building
+	BuildingNumber string   `json:"buildingNumber" bson:"buildingNumber"`
+	Rooms          []string `json:"rooms" bson:"rooms"`
+	FloorPlanPath  string   `json:"floorPlanPath" bson:"floorPlanPath"` //the floor plan with the room highlighted, relative to the client API
+}
+
+// DescriptionLink represents a link found in the event description
+type DescriptionLink struct {
+	Text string `json:"text" bson:"text"`
+	URL  string `json:"url" bson:"url"`
 }
 
 // SubEvents represents the sub events
@@ -208,13 +341,193 @@ type SubEvents struct {
 
 // LegacyEventItem represents legacy event entity which contains legacy event + other sync info
 type LegacyEventItem struct {
-	SyncProcessSource string            `bson:"sync_process_source"` //webtools-direct or events-bb-initial or events-tps-api
+	OrgID string `bson:"org_id"`
+	AppID string `bson:"app_id"`
+
+	SyncProcessSource string            `bson:"sync_process_source"` //webtools-direct or sidearm-direct or events-bb-initial or events-tps-api
 	SyncDate          time.Time         `bson:"sync_date"`
 	Status            LegacyEventStatus `bson:"status"`
 
+	//parsed item start and end dates used for querying
+	StartDate *time.Time `bson:"start_date"`
+	EndDate   *time.Time `bson:"end_date"`
+	//item location used for geospatial querying
+	GeoLocation *GeoJSONPoint `bson:"geo_location"`
+
 	Item LegacyEvent `bson:"item"`
 
 	CreateInfo *CreateInfo `bson:"create_info"`
+
+	Version int `bson:"version"` //the version of the last history record
+
+	Moderation *LegacyEventModeration `bson:"moderation"` //only for the events of the moderated third-party service accounts
+
+	CategoryMapping *LegacyEventCategoryMapping `bson:"category_mapping"` //the category conversion applied to a third-party service event
+}
+
+// LegacyEventCategoryMapping represents the conversion of the submitted event category to the gateway one
+type LegacyEventCategoryMapping struct {
+	Original string `json:"original" bson:"original"`
+	Mapped   string `json:"mapped" bson:"mapped"`
+}
+
+const (
+	//LegacyEventStatusValid the event is shown to the users
+	LegacyEventStatusValid string = "valid"
+	//LegacyEventStatusPending the event waits for moderation
+	LegacyEventStatusPending string = "pending"
+	//LegacyEventStatusRejected the event has been rejected by a moderator
+	LegacyEventStatusRejected string = "rejected"
+)
+
+// LegacyEventModeration represents the moderation of an event created by a moderated third-party service account
+type LegacyEventModeration struct {
+	Status        string     `json:"status" bson:"status"` //pending, approved or rejected
+	Reason        string     `json:"reason" bson:"reason"` //the reason of the rejection
+	ModeratorID   string     `json:"moderator_id" bson:"moderator_id"`
+	DateModerated *time.Time `json:"date_moderated" bson:"date_moderated"`
+}
+
+const (
+	//LegacyEventModerationPending the event waits for moderation
+	LegacyEventModerationPending string = "pending"
+	//LegacyEventModerationApproved the event has been approved, it is valid
+	LegacyEventModerationApproved string = "approved"
+	//LegacyEventModerationRejected the event has been rejected
+	LegacyEventModerationRejected string = "rejected"
+)
+
+// TPSEventModeration represents the moderation of an event as seen by the third-party service account which created it
+type TPSEventModeration struct {
+	ID         string                `json:"id"`
+	Title      string                `json:"title"`
+	Moderation LegacyEventModeration `json:"moderation"`
+}
+
+// LegacyEventHistoryItem represents a version of legacy event with the changes from the previous one
+type LegacyEventHistoryItem struct {
+	ID          string                   `json:"id" bson:"_id"`
+	OrgID       string                   `json:"org_id" bson:"org_id"`
+	AppID       string                   `json:"app_id" bson:"app_id"`
+	EventID     string                   `json:"event_id" bson:"event_id"`
+	Version     int                      `json:"version" bson:"version"`
+	Operation   string                   `json:"operation" bson:"operation"` //created, updated or deleted
+	Source      string                   `json:"source" bson:"source"`       //webtools-direct or sidearm-direct or events-tps-api
+	Actor       string                   `json:"actor" bson:"actor"`         //the account id or the sync run id
+	Changes     []LegacyEventFieldChange `json:"changes" bson:"changes"`
+	DateCreated time.Time                `json:"date_created" bson:"date_created"`
+}
+
+const (
+	//LegacyEventHistoryCreated the event has been created
+	LegacyEventHistoryCreated string = "created"
+	//LegacyEventHistoryUpdated the event has been updated
+	LegacyEventHistoryUpdated string = "updated"
+	//LegacyEventHistoryDeleted the event has been deleted
+	LegacyEventHistoryDeleted string = "deleted"
+)
+
+// LegacyEventFieldChange represents a change of legacy event field
+type LegacyEventFieldChange struct {
+	Field string      `json:"field" bson:"field"` //the path of the field, for example item.location.description
+	Old   interface{} `json:"old" bson:"old"`
+	New   interface{} `json:"new" bson:"new"`
+}
+
+// LegacyEventsReconciliation represents the comparison of the events BB legacy events with the gateway ones
+type LegacyEventsReconciliation struct {
+	ID    string `json:"id"`
+	OrgID string `json:"org_id"`
+	AppID string `json:"app_id"`
+
+	EventsBBCount int `json:"events_bb_count"`
+	GatewayCount  int `json:"gateway_count"`
+	MatchedCount  int `json:"matched_count"`
+
+	Missing   []LegacyEventReconciliationItem `json:"missing"`  //in the events BB only
+	Existing  []LegacyEventReconciliationItem `json:"existing"` //in the events BB, the gateway has not valid, archived or other org and app events with the ids
+	Extra     []LegacyEventReconciliationItem `json:"extra"`    //in the gateway only
+	Divergent []LegacyEventDivergence         `json:"divergent"`
+
+	BackfilledGatewayCount  int                             `json:"backfilled_gateway_count"`
+	BackfilledEventsBBCount int                             `json:"backfilled_events_bb_count"`
+	FailedEventsBB          []LegacyEventReconciliationItem `json:"failed_events_bb"` //could not be created in the events BB
+
+	DateCreated time.Time `json:"date_created"`
+}
+
+// LegacyEventReconciliationItem represents legacy event found only in the events BB or only in the gateway
+type LegacyEventReconciliationItem struct {
+	ID                string `json:"id"`
+	DataSourceEventID string `json:"data_source_event_id"`
+	Title             string `json:"title"`
+}
+
+// LegacyEventDivergence represents legacy event which has different values in the events BB and in the gateway
+type LegacyEventDivergence struct {
+	ID                string                       `json:"id"` //the gateway event id
+	EventsBBID        string                       `json:"events_bb_id"`
+	DataSourceEventID string                       `json:"data_source_event_id"`
+	Title             string                       `json:"title"`
+	Differences       []LegacyEventFieldDifference `json:"differences"`
+}
+
+// LegacyEventFieldDifference represents legacy event field which has different values in the events BB and in the gateway
+type LegacyEventFieldDifference struct {
+	Field    string      `json:"field"`
+	Gateway  interface{} `json:"gateway"`
+	EventsBB interface{} `json:"events_bb"`
+}
+
+// ArchivedLegacyEventItem represents legacy event item moved into the archive once the event has ended
+type ArchivedLegacyEventItem struct {
+	LegacyEventItem `bson:",inline"`
+
+	ArchivedAt time.Time `bson:"archived_at"`
+	PurgeAt    time.Time `bson:"purge_at"` //the archived item is removed after it
+}
+
+// LegacyEventsRetention represents the retention policy of the legacy events
+type LegacyEventsRetention struct {
+	ArchiveAfter time.Duration //the events are archived after they have ended for this period
+	PurgeAfter   time.Duration //the archived events are purged after they have ended for this period
+}
+
+const (
+	defaultArchiveAfterDays = 1
+	defaultPurgeAfterDays   = 730
+)
+
+// NewLegacyEventsRetention creates the retention policy from the numbers of days, the defaults are used for empty values
+func NewLegacyEventsRetention(archiveAfterDays string, purgeAfterDays string) (*LegacyEventsRetention, error) {
+	archiveAfter := defaultArchiveAfterDays
+	if len(archiveAfterDays) > 0 {
+		days, err := strconv.Atoi(archiveAfterDays)
+		if err != nil || days < 0 {
+			return nil, errors.ErrorData(logutils.StatusInvalid, "archive after days", &logutils.FieldArgs{"value": archiveAfterDays})
+		}
+		archiveAfter = days
+	}
+
+	purgeAfter := defaultPurgeAfterDays
+	if len(purgeAfterDays) > 0 {
+		days, err := strconv.Atoi(purgeAfterDays)
+		if err != nil || days <= archiveAfter {
+			return nil, errors.ErrorData(logutils.StatusInvalid, "purge after days", &logutils.FieldArgs{"value": purgeAfterDays, "archive_after_days": archiveAfter})
+		}
+		purgeAfter = days
+	}
+
+	day := 24 * time.Hour
+	return &LegacyEventsRetention{ArchiveAfter: time.Duration(archiveAfter) * day, PurgeAfter: time.Duration(purgeAfter) * day}, nil
+}
+
+// EndedBefore says if the event has ended before the date. The start date is used for the events without end date.
+func (item LegacyEventItem) EndedBefore(date time.Time) bool {
+	if item.EndDate != nil {
+		return item.EndDate.Before(date)
+	}
+	return item.StartDate != nil && item.StartDate.Before(date)
 }
 
 // LegacyEventStatus represents legacy event status
@@ -235,3 +548,82 @@ type CreateInfo struct {
 	Time      time.Time `json:"time" bson:"time"`
 	AccountID string    `json:"account_id" bson:"account_id"`
 }
+
+// NearbyLegacyEvent represents legacy event found near a point
+type NearbyLegacyEvent struct {
+	LegacyEvent
+	Distance float64 `json:"distance"` //in meters
+}
+
+// GeoJSONPoint represents GeoJSON point
+type GeoJSONPoint struct {
+	Type        string    `json:"type" bson:"type"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"` //longitude, latitude
+}
+
+// NewGeoJSONPoint creates GeoJSON point, nil for missing or invalid coordinates
+func NewGeoJSONPoint(latitude float64, longitude float64) *GeoJSONPoint {
+	if latitude == 0 && longitude == 0 {
+		return nil
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil
+	}
+	return &GeoJSONPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// LegacyEventsQuery represents the criteria for searching legacy events
+type LegacyEventsQuery struct {
+	//the org and app the events belong to
+	OrgID string
+	AppID string
+
+	Text                   string   //words and "quoted phrases" to search for
+	Categories             []string //any of the categories
+	CalendarIDs            []string //any of the calendars
+	OriginatingCalendarIDs []string //any of the originating calendars
+	Audiences              []string //any of the target audiences
+	AttendanceModes        []string //any of the attendance modes
+	Source                 *string
+	Status                 *string
+	CreatorAccountID       *string //the third-party service account which created the events
+	FreeForStudents        bool    //only the events which are free for the students
+
+	//the events which take place in the period
+	From *time.Time
+	To   *time.Time
+
+	Limit  int64
+	Offset int64
+}
+
+// HasCriteria checks if the query has text or a filter which narrows the events down
+func (q LegacyEventsQuery) HasCriteria() bool {
+	return len(q.Text) > 0 || len(q.Categories) > 0 || len(q.CalendarIDs) > 0 || len(q.OriginatingCalendarIDs) > 0 ||
+		len(q.Audiences) > 0 || len(q.AttendanceModes) > 0 || q.FreeForStudents || q.From != nil || q.To != nil
+}
+
+// legacyEventDateLayouts are the date formats used by the legacy events sources
+var legacyEventDateLayouts = []string{
+	"Mon, 02 Jan 2006 15:04:05 GMT",
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseLegacyEventDate parses a legacy event start or end date, nil if the date cannot be parsed
+func ParseLegacyEventDate(value string) *time.Time {
+	if len(value) == 0 {
+		return nil
+	}
+	for _, layout := range legacyEventDateLayouts {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			date = date.UTC()
+			return &date
+		}
+	}
+	return nil
+}
@@ -22,3 +22,12 @@ func (d *DefaultStorageListener) OnConfigsUpdated() {}
 
 // OnExamplesUpdated notifies that the examples collection has been updated
 func (d *DefaultStorageListener) OnExamplesUpdated() {}
+
+// OnBuildingFeaturesUpdated notifies that the building features catalog has been updated
+func (d *DefaultStorageListener) OnBuildingFeaturesUpdated() {}
+
+// OnManagedFeatureLocationsUpdated notifies that the managed feature locations have been updated
+func (d *DefaultStorageListener) OnManagedFeatureLocationsUpdated() {}
+
+// OnBuildingAliasesUpdated notifies that the building aliases have been updated
+func (d *DefaultStorageListener) OnBuildingAliasesUpdated() {}
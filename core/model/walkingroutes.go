@@ -0,0 +1,55 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeWalkingPath type
+	TypeWalkingPath logutils.MessageDataType = "walking path"
+	//TypeWalkingRoute type
+	TypeWalkingRoute logutils.MessageDataType = "walking route"
+)
+
+// WalkingPath represents a pedestrian way of the campus paths extract, like a sidewalk, a footway or a flight of stairs
+type WalkingPath struct {
+	ID          string
+	Name        string
+	Coordinates [][]float64 //longitude, latitude pairs in the walking order
+	Stairs      bool        //not walkable in the accessible mode
+}
+
+// WalkingRoute represents the walking directions between two campus points
+type WalkingRoute struct {
+	Distance    float64     //in meters
+	Duration    float64     //in seconds at the walking speed
+	Accessible  bool        //true when the route avoids the stairs
+	Building    *Building   //the destination building, nil when the destination is a point
+	Entrance    *Entrance   //the building entrance the route ends at
+	Coordinates [][]float64 //longitude, latitude pairs from the origin to the destination
+	Steps       []WalkingRouteStep
+}
+
+// WalkingRouteStep represents a step of the walking directions like "Turn left onto Green Street"
+type WalkingRouteStep struct {
+	Instruction string
+	Name        string  //the name of the walked path, empty when it is unnamed
+	Distance    float64 //in meters
+	Bearing     float64 //the direction at the start of the step in degrees clockwise from the north
+	Stairs      bool
+	Coordinates [][]float64
+}
@@ -16,6 +16,7 @@ package eventsbb
 
 import (
 	"application/core/model"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -81,3 +82,38 @@ func (na Adapter) LoadAllLegacyEvents() ([]model.LegacyEvent, error) {
 	return list, nil
 
 }
+
+// CreateLegacyEvent creates the legacy event in the events BB
+func (na Adapter) CreateLegacyEvent(event model.LegacyEvent) error {
+	url := fmt.Sprintf("%s/events", na.baseURL)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		na.log.Errorf("legacy_events.CreateLegacyEvent: error marshalling legacy event %s - %s", event.ID, err)
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		na.log.Errorf("legacy_events.CreateLegacyEvent: error creating create legacy event request - %s", err)
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ROKWIRE-API-KEY", na.apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		na.log.Errorf("legacy_events.CreateLegacyEvent: error sending create legacy event request - %s", err)
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		errorResponse, _ := io.ReadAll(resp.Body)
+		na.log.Errorf("legacy_events.CreateLegacyEvent: error with response code %d - %s", resp.StatusCode, errorResponse)
+		return fmt.Errorf("CreateLegacyEvent: error with response code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprints
+
+import (
+	"application/core/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// featureCollection is a GeoJSON feature collection
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature is a GeoJSON feature
+type feature struct {
+	Geometry   *geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geometry is a GeoJSON geometry, only the polygons are used
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParseFootprints parses the footprints of a GeoJSON feature collection. The Polygon and MultiPolygon features
+// with a "number" property, the building number, are the footprints. The polygons of the same building are merged
+func ParseFootprints(data []byte) ([]model.BuildingFootprint, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty building footprints")
+	}
+
+	var collection featureCollection
+	err := json.Unmarshal(data, &collection)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing building footprints: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("building footprints are a %s instead of a FeatureCollection", collection.Type)
+	}
+
+	footprints := []model.BuildingFootprint{}
+	indexes := map[string]int{}
+	for i, f := range collection.Features {
+		number := buildingNumber(f.Properties)
+		if f.Geometry == nil || len(number) == 0 {
+			continue
+		}
+
+		var polygons [][][][]float64
+		switch f.Geometry.Type {
+		case "Polygon":
+			var polygon [][][]float64
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygon)
+			polygons = [][][][]float64{polygon}
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygons)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the coordinates of building footprint %d: %w", i, err)
+		}
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				for _, position := range ring {
+					if len(position) < 2 {
+						return nil, fmt.Errorf("invalid position in the footprint of building %s", number)
+					}
+				}
+			}
+		}
+
+		if index, ok := indexes[number]; ok {
+			footprints[index].Polygons = append(footprints[index].Polygons, polygons...)
+			continue
+		}
+		indexes[number] = len(footprints)
+		footprints = append(footprints, model.BuildingFootprint{BuildingNumber: number, Polygons: polygons})
+	}
+	return footprints, nil
+}
+
+// buildingNumber gives the building number property, empty when it is missing
+func buildingNumber(properties map[string]interface{}) string {
+	switch value := properties["number"].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprints
+
+import (
+	"application/core/model"
+	"application/utils"
+)
+
+// FileSource loads the footprints from a GeoJSON file stored with the gateway
+type FileSource struct {
+	file utils.DataFile
+}
+
+// LoadFootprints loads the footprints from the file
+func (s FileSource) LoadFootprints() ([]model.BuildingFootprint, error) {
+	data, err := s.file.Read()
+	if err != nil {
+		return nil, err
+	}
+	return ParseFootprints(data)
+}
+
+// NewFileSource creates new source of the footprints file
+func NewFileSource(path string) (*FileSource, error) {
+	file, err := utils.NewDataFile(path, "building footprints")
+	if err != nil {
+		return nil, err
+	}
+	return &FileSource{file: *file}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprints
+
+import (
+	"application/core/model"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseFootprints(t *testing.T) {
+	data, err := os.ReadFile("testdata/footprints.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	footprints, err := ParseFootprints(data)
+	if err != nil {
+		t.Fatalf("ParseFootprints() error = %v", err)
+	}
+
+	want := []model.BuildingFootprint{
+		{BuildingNumber: "0041", Polygons: [][][][]float64{
+			{{{-88.2275, 40.1124}, {-88.2265, 40.1124}, {-88.2265, 40.1130}, {-88.2275, 40.1124}}},
+			{{{-88.2262, 40.1124}, {-88.2260, 40.1124}, {-88.2260, 40.1126}, {-88.2262, 40.1124}}},
+		}},
+		{BuildingNumber: "112", Polygons: [][][][]float64{
+			{{{-88.2290, 40.1110}, {-88.2285, 40.1110}, {-88.2285, 40.1115}, {-88.2290, 40.1110}}},
+			{{{-88.2280, 40.1110}, {-88.2278, 40.1110}, {-88.2278, 40.1112}, {-88.2280, 40.1110}}},
+		}},
+	}
+	if !reflect.DeepEqual(footprints, want) {
+		t.Errorf("ParseFootprints() = %+v\nwant %+v", footprints, want)
+	}
+}
+
+func TestParseFootprintsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: "\n"},
+		{name: "not json", data: "number,polygon"},
+		{name: "not a feature collection", data: `{"type": "Feature", "geometry": null}`},
+		{name: "invalid coordinates", data: `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"number": "1"},
+			"geometry": {"type": "Polygon", "coordinates": [[-88.22, 40.11], [-88.21, 40.11]]}}]}`},
+		{name: "invalid position", data: `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"number": "1"},
+			"geometry": {"type": "Polygon", "coordinates": [[[-88.22, 40.11], [-88.21]]]}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFootprints([]byte(tt.data))
+			if err == nil {
+				t.Error("ParseFootprints() error = nil, want error")
+			}
+		})
+	}
+}
@@ -18,31 +18,35 @@ import (
 	"application/core/model"
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"image"
 	"image/png"
-	"io"
 	"log"
-	"mime/multipart"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 // Adapter implements the Image interface
 type Adapter struct {
-	baseURL        string
-	accountManager *auth.ServiceAccountManager
+	renditions []model.ImageRendition
+	storage    imageStorage
 
 	logger     logs.Logger
 	httpClient *http.Client
 }
 
+// imageStorage keeps the uploaded image renditions
+type imageStorage interface {
+	upload(imageData []byte, width int, height int, quality int, path string, fileName string) (string, error)
+	load(key string) ([]byte, string, error) //nil data when the image is missing
+}
+
 // ProcessImage process an image
 func (im Adapter) ProcessImage(item model.WebToolsEvent) (*model.ContentImagesURL, error) {
 	//downlaod
@@ -57,20 +61,48 @@ func (im Adapter) ProcessImage(item model.WebToolsEvent) (*model.ContentImagesUR
 		return nil, nil
 	}
 
-	//upload
-	uploadImageFromContent, err := im.uploadImageFromContent(webtoolsImage.ImageData,
-		webtoolsImage.Height, webtoolsImage.Width, webtoolsImage.Quality,
-		webtoolsImage.Path, webtoolsImage.FileName)
-	if err != nil {
-		im.logger.Infof("Error with uploading image from content - %s", err)
-		return nil, err
+	//upload every rendition
+	images := make(map[string]string, len(im.renditions))
+	for _, rendition := range im.renditions {
+		width, height := rendition.Fit(webtoolsImage.Width, webtoolsImage.Height)
+		url, err := im.storage.upload(webtoolsImage.ImageData,
+			width, height, rendition.Quality,
+			webtoolsImage.Path, webtoolsImage.FileName)
+		if err != nil {
+			im.logger.Infof("Error with uploading %s image from content - %s", rendition.Name, err)
+			return nil, err
+		}
+		images[rendition.Name] = url
 	}
 
-	res := model.ContentImagesURL{ID: item.EventID, ImageURL: uploadImageFromContent}
+	res := model.ContentImagesURL{ID: item.EventID, ImageURL: im.mainImageURL(images), Images: images}
 
 	return &res, nil
 }
 
+// LoadImage gives the content and the content type of a stored image. A key which was not generated by the gateway is invalid
+func (im Adapter) LoadImage(key string) ([]byte, string, error) {
+	err := validateImageKey(key)
+	if err != nil {
+		return nil, "", errors.WrapErrorData(logutils.StatusInvalid, model.TypeImage, &logutils.FieldArgs{"key": key}, err).SetStatus(string(logutils.StatusInvalid))
+	}
+	return im.storage.load(key)
+}
+
+// mainImageURL gives the url kept in imageURL for the clients which do not support renditions
+func (im Adapter) mainImageURL(images map[string]string) string {
+	if url, ok := images[model.ImageRenditionFull]; ok {
+		return url
+	}
+	//the last configured rendition is the biggest one
+	for i := len(im.renditions) - 1; i >= 0; i-- {
+		if url, ok := images[im.renditions[i].Name]; ok {
+			return url
+		}
+	}
+	return ""
+}
+
 func (im Adapter) downloadWebtoolImages(item model.WebToolsEvent) (*model.ImageData, error) {
 
 	if item.ImageUploaded != "true" {
@@ -176,98 +208,29 @@ func (im Adapter) downloadWebtoolImages(item model.WebToolsEvent) (*model.ImageD
 	}, nil
 }
 
-// Function to upload image to another API along with additional data
-func (im Adapter) uploadImageFromContent(imageData []byte, height int, width int, quality int, path, fileName string) (string, error) {
-	// URL to which the request will be sent
-	targetURL := fmt.Sprintf("%s/content/bbs/image", im.baseURL)
-
-	// Send the request and get the response
-	respData, err := im.sendRequest(targetURL, path, width, height, quality, string(imageData))
-	if err != nil {
-		fmt.Println("Error:", err)
-		return "", err
-	}
-
-	type response struct {
-		URL string `json:"url"`
-	}
-
-	var resp response
-	err = json.Unmarshal([]byte(respData), &resp)
-	if err != nil {
-		fmt.Println("Error unmarshalling response:", err)
-		return "", err
-	}
-
-	return resp.URL, nil
+// NewImageAdapter creates a new image adapter instance which uploads the images to the Content BB
+func NewImageAdapter(imageHost string, renditions []model.ImageRendition, accountManager *auth.ServiceAccountManager, logger logs.Logger) *Adapter {
+	storage := contentBBStorage{baseURL: imageHost, accountManager: accountManager}
+	return newAdapter(storage, renditions, logger)
 }
 
-func (im Adapter) sendRequest(targetURL, path string, width, height, quality int, filePath string) (string, error) {
-	// Create a buffer to hold the multipart form data
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
-
-	// Add form fields: path, width, height and quality
-	_ = writer.WriteField("path", path)
-	_ = writer.WriteField("width", strconv.Itoa(width))
-	_ = writer.WriteField("height", strconv.Itoa(height))
-	_ = writer.WriteField("quality", strconv.Itoa(quality))
-
-	// Add the image file to the multipart form
-	fileWriter, err := writer.CreateFormFile("fileName", "image.jpg")
-	if err != nil {
-		return "", fmt.Errorf("error creating form file: %w", err)
-	}
-
-	// Copy image data into the multipart file field
-	_, err = io.Copy(fileWriter, bytes.NewReader([]byte(filePath)))
-	if err != nil {
-		return "", fmt.Errorf("error copying file data: %w", err)
-	}
-
-	// Close the multipart writer to finalize the request body
-	writer.Close()
-
-	// Create a context with timeout to avoid hanging requests
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	// Create the HTTP POST request with the timeout context
-	request, err := http.NewRequestWithContext(ctx, "POST", targetURL, &requestBody)
-	if err != nil {
-		return "", fmt.Errorf("error creating HTTP request: %w", err)
-	}
-
-	// Set the correct Content-Type for multipart form data
-	request.Header.Set("Content-Type", writer.FormDataContentType())
-
-	// Send the request using the account manager (adds auth, headers, etc.)
-	response, err := im.accountManager.MakeRequest(request, "all", "all")
-	if err != nil {
-		log.Printf("error sending request - %s", err)
-		return "", err
-	}
-	defer response.Body.Close()
-
-	// Validate successful response status
-	if response.StatusCode != http.StatusOK {
-		log.Printf("error with response code from ContentBB - %d", response.StatusCode)
-		return "", fmt.Errorf("error with response code != 200")
-	}
-
-	// Read the response body
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		return "", fmt.Errorf("error reading response body: %w", err)
-	}
+// NewLocalImageAdapter creates a new image adapter instance which keeps the images in a local directory
+func NewLocalImageAdapter(dir string, publicURL string, renditions []model.ImageRendition, logger logs.Logger) *Adapter {
+	storage := localStorage{dir: dir, publicURL: publicURL}
+	return newAdapter(storage, renditions, logger)
+}
 
-	// Return the response as string
-	return string(responseBody), nil
+// NewS3ImageAdapter creates a new image adapter instance which keeps the images in an S3 compatible bucket
+func NewS3ImageAdapter(config S3Config, publicURL string, renditions []model.ImageRendition, logger logs.Logger) *Adapter {
+	storage := s3Storage{config: config, publicURL: publicURL, httpClient: &http.Client{Timeout: 20 * time.Second}}
+	return newAdapter(storage, renditions, logger)
 }
 
-// NewImageAdapter creates a new image adapter instance
-func NewImageAdapter(imageHost string, accountManager *auth.ServiceAccountManager, logger logs.Logger) *Adapter {
-	return &Adapter{baseURL: imageHost, accountManager: accountManager, logger: logger, httpClient: &http.Client{
+func newAdapter(storage imageStorage, renditions []model.ImageRendition, logger logs.Logger) *Adapter {
+	if len(renditions) == 0 {
+		renditions = model.DefaultImageRenditions
+	}
+	return &Adapter{renditions: renditions, storage: storage, logger: logger, httpClient: &http.Client{
 		Timeout: 10 * time.Second,
 	}}
 }
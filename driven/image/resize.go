@@ -0,0 +1,99 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // register the png decoder
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var imageKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_\-/]+\.jpg$`)
+
+// encodeRendition scales the image to the rendition size and encodes it as JPEG with the rendition quality
+func encodeRendition(imageData []byte, width int, height int, quality int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+
+	dst := src
+	bounds := src.Bounds()
+	if width > 0 && height > 0 && (width != bounds.Dx() || height != bounds.Dy()) {
+		dst = scaleDown(src, width, height)
+	}
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleDown resizes the image by averaging the source pixels covered by every destination pixel
+func scaleDown(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					count++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / count), G: uint16(g / count), B: uint16(b / count), A: uint16(a / count)})
+		}
+	}
+	return dst
+}
+
+// newImageKey generates a unique key for an image rendition under the given path
+func newImageKey(path string) string {
+	path = strings.Trim(path, "/")
+	if len(path) == 0 {
+		return uuid.NewString() + ".jpg"
+	}
+	return path + "/" + uuid.NewString() + ".jpg"
+}
+
+// validateImageKey makes sure the key is one generated by newImageKey
+func validateImageKey(key string) error {
+	if !imageKeyRegex.MatchString(key) || strings.Contains(key, "//") {
+		return errors.New("invalid image key")
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return errors.New("invalid image key")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth"
+)
+
+// contentBBStorage uploads the images to the Content BB
+type contentBBStorage struct {
+	baseURL        string
+	accountManager *auth.ServiceAccountManager
+}
+
+// Function to upload image to another API along with additional data
+func (s contentBBStorage) upload(imageData []byte, width int, height int, quality int, path string, fileName string) (string, error) {
+	// URL to which the request will be sent
+	targetURL := fmt.Sprintf("%s/content/bbs/image", s.baseURL)
+
+	// Send the request and get the response
+	respData, err := s.sendRequest(targetURL, path, width, height, quality, string(imageData))
+	if err != nil {
+		return "", err
+	}
+
+	type response struct {
+		URL string `json:"url"`
+	}
+
+	var resp response
+	err = json.Unmarshal([]byte(respData), &resp)
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling the Content BB response: %w", err)
+	}
+
+	return resp.URL, nil
+}
+
+func (s contentBBStorage) sendRequest(targetURL, path string, width, height, quality int, filePath string) (string, error) {
+	// Create a buffer to hold the multipart form data
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+
+	// Add form fields: path, width, height and quality
+	_ = writer.WriteField("path", path)
+	_ = writer.WriteField("width", strconv.Itoa(width))
+	_ = writer.WriteField("height", strconv.Itoa(height))
+	_ = writer.WriteField("quality", strconv.Itoa(quality))
+
+	// Add the image file to the multipart form
+	fileWriter, err := writer.CreateFormFile("fileName", "image.jpg")
+	if err != nil {
+		return "", fmt.Errorf("error creating form file: %w", err)
+	}
+
+	// Copy image data into the multipart file field
+	_, err = io.Copy(fileWriter, bytes.NewReader([]byte(filePath)))
+	if err != nil {
+		return "", fmt.Errorf("error copying file data: %w", err)
+	}
+
+	// Close the multipart writer to finalize the request body
+	writer.Close()
+
+	// Create a context with timeout to avoid hanging requests
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// Create the HTTP POST request with the timeout context
+	request, err := http.NewRequestWithContext(ctx, "POST", targetURL, &requestBody)
+	if err != nil {
+		return "", fmt.Errorf("error creating HTTP request: %w", err)
+	}
+
+	// Set the correct Content-Type for multipart form data
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Send the request using the account manager (adds auth, headers, etc.)
+	response, err := s.accountManager.MakeRequest(request, "all", "all")
+	if err != nil {
+		return "", fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Validate successful response status
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error with response code from the Content BB - %d", response.StatusCode)
+	}
+
+	// Read the response body
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading response body: %w", err)
+	}
+
+	// Return the response as string
+	return string(responseBody), nil
+}
+
+// load finds no images as the Content BB serves its images itself
+func (s contentBBStorage) load(key string) ([]byte, string, error) {
+	return nil, "", nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// localStorage keeps the images in a local directory. They are served by the gateway.
+type localStorage struct {
+	dir       string
+	publicURL string
+}
+
+func (s localStorage) upload(imageData []byte, width int, height int, quality int, path string, fileName string) (string, error) {
+	data, err := encodeRendition(imageData, width, height, quality)
+	if err != nil {
+		return "", err
+	}
+
+	key := newImageKey(path)
+	filePath := filepath.Join(s.dir, filepath.FromSlash(key))
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating image directory: %w", err)
+	}
+	err = os.WriteFile(filePath, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error writing image file: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%s", s.publicURL, key), nil
+}
+
+func (s localStorage) load(key string) ([]byte, string, error) {
+	err := validateImageKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return data, "image/jpeg", nil
+}
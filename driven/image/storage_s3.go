@@ -0,0 +1,153 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config keeps the settings of an S3 compatible bucket (AWS S3, MinIO etc)
+type S3Config struct {
+	Endpoint  string //for example https://s3.us-east-2.amazonaws.com or http://localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// s3Storage keeps the images in an S3 compatible bucket. The bucket is accessed with path style requests
+// signed with AWS Signature Version 4, the images are served by the gateway.
+type s3Storage struct {
+	config     S3Config
+	publicURL  string
+	httpClient *http.Client
+}
+
+func (s s3Storage) upload(imageData []byte, width int, height int, quality int, path string, fileName string) (string, error) {
+	data, err := encodeRendition(imageData, width, height, quality)
+	if err != nil {
+		return "", err
+	}
+
+	key := newImageKey(path)
+	resp, err := s.doRequest(http.MethodPut, key, data, "image/jpeg")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error uploading image to the bucket - %d %s", resp.StatusCode, string(body))
+	}
+
+	return fmt.Sprintf("%s/%s", s.publicURL, key), nil
+}
+
+func (s s3Storage) load(key string) ([]byte, string, error) {
+	err := validateImageKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := s.doRequest(http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("error loading image from the bucket - %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading image from the bucket: %w", err)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if len(contentType) == 0 {
+		contentType = "image/jpeg"
+	}
+	return data, contentType, nil
+}
+
+func (s s3Storage) doRequest(method string, key string, body []byte, contentType string) (*http.Response, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(s.config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bucket endpoint: %w", err)
+	}
+	endpoint.Path = fmt.Sprintf("%s/%s/%s", endpoint.Path, s.config.Bucket, key)
+
+	req, err := http.NewRequest(method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating bucket request: %w", err)
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	return s.httpClient.Do(req)
+}
+
+// sign adds AWS Signature Version 4 authorization to the request
+func (s s3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	canonicalRequest := strings.Join([]string{req.Method, req.URL.EscapedPath(), req.URL.RawQuery,
+		canonicalHeaders, signedHeaders, payloadHash}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.config.Region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
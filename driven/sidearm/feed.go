@@ -0,0 +1,154 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidearm
+
+import (
+	"application/core/model"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// schedule is the XML schedule feed
+type schedule struct {
+	XMLName xml.Name   `xml:"schedule"`
+	Games   []feedGame `xml:"game"`
+}
+
+// jsonSchedule is the JSON schedule feed, it gives the games with the same fields as the XML one
+type jsonSchedule struct {
+	Games []feedGame `json:"games"`
+}
+
+// feedGame is a game in the XML and JSON schedule feeds
+type feedGame struct {
+	ID                string `xml:"id" json:"id"`
+	Date              string `xml:"date" json:"date"`
+	Time              string `xml:"time" json:"time"`
+	Sport             string `xml:"sport" json:"sport"`
+	Opponent          string `xml:"opponent" json:"opponent"`
+	LocationIndicator string `xml:"location_indicator" json:"location_indicator"` //H, A or N
+	Location          string `xml:"location" json:"location"`
+	Facility          string `xml:"facility" json:"facility"`
+	TicketsURL        string `xml:"tickets_url" json:"tickets_url"`
+	GameURL           string `xml:"game_url" json:"game_url"`
+}
+
+// ParseFeed parses the XML or the JSON schedule feed, the games times are in the Central time zone
+func ParseFeed(data []byte) ([]model.SidearmGame, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty sidearm feed")
+	}
+
+	var feedGames []feedGame
+	switch data[0] {
+	case '<':
+		var feed schedule
+		err := xml.Unmarshal(data, &feed)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sidearm xml feed: %w", err)
+		}
+		feedGames = feed.Games
+	case '[':
+		err := json.Unmarshal(data, &feedGames)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sidearm json feed: %w", err)
+		}
+	case '{':
+		var feed jsonSchedule
+		err := json.Unmarshal(data, &feed)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sidearm json feed: %w", err)
+		}
+		feedGames = feed.Games
+	default:
+		return nil, errors.New("unknown sidearm feed format")
+	}
+
+	location, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		return nil, fmt.Errorf("error loading sidearm feed time zone: %w", err)
+	}
+
+	games := make([]model.SidearmGame, 0, len(feedGames))
+	for _, g := range feedGames {
+		id := strings.TrimSpace(g.ID)
+		if len(id) == 0 {
+			continue
+		}
+		startDate, allDay, err := parseGameDate(strings.TrimSpace(g.Date), strings.TrimSpace(g.Time), location)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the date of sidearm game %s: %w", id, err)
+		}
+
+		games = append(games, model.SidearmGame{ID: id, Sport: strings.TrimSpace(g.Sport), Opponent: strings.TrimSpace(g.Opponent),
+			HomeAway: parseHomeAway(g.LocationIndicator), Venue: strings.TrimSpace(g.Facility), City: strings.TrimSpace(g.Location),
+			StartDate: startDate, AllDay: allDay, TicketURL: strings.TrimSpace(g.TicketsURL), GameURL: strings.TrimSpace(g.GameURL)})
+	}
+	return games, nil
+}
+
+var gameDateLayouts = []string{"1/2/2006", "2006-01-02", "2006-01-02T15:04:05"}
+
+var gameTimeLayouts = []string{"3:04 PM", "3:04PM", "3 PM", "3PM", "15:04"}
+
+// parseGameDate gives the game start, the game is all day when the time is not announced
+func parseGameDate(date string, gameTime string, location *time.Location) (time.Time, bool, error) {
+	var day time.Time
+	var err error
+	for _, layout := range gameDateLayouts {
+		day, err = time.ParseInLocation(layout, date, location)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	//the time may be followed by the time zone, for example 7:00 PM CT
+	gameTime = strings.ToUpper(gameTime)
+	for _, zone := range []string{" CT", " CST", " CDT"} {
+		gameTime = strings.TrimSuffix(gameTime, zone)
+	}
+	for _, layout := range gameTimeLayouts {
+		clock, err := time.Parse(layout, gameTime)
+		if err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location), false, nil
+		}
+	}
+	if day.Hour() != 0 || day.Minute() != 0 {
+		//the date contains the time
+		return day, false, nil
+	}
+	//TBA, All Day and similar
+	return day, true, nil
+}
+
+func parseHomeAway(indicator string) string {
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "H", "HOME":
+		return model.LegacyEventAthleticsHome
+	case "A", "AWAY":
+		return model.LegacyEventAthleticsAway
+	default:
+		return model.LegacyEventAthleticsNeutral
+	}
+}
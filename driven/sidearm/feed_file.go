@@ -0,0 +1,44 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidearm
+
+import (
+	"application/core/model"
+	"application/utils"
+)
+
+// FileFeed loads the feed saved in a local XML or JSON file, for example a fixture of the real feed.
+// It allows running the sync offline, the configured feed url is not used.
+type FileFeed struct {
+	file utils.DataFile
+}
+
+// LoadGames loads the games from the saved feed
+func (f FileFeed) LoadGames(feedURL string) ([]model.SidearmGame, error) {
+	data, err := f.file.Read()
+	if err != nil {
+		return nil, err
+	}
+	return ParseFeed(data)
+}
+
+// NewFileFeed creates new feed of the saved file
+func NewFileFeed(path string) (*FileFeed, error) {
+	file, err := utils.NewDataFile(path, "sidearm feed")
+	if err != nil {
+		return nil, err
+	}
+	return &FileFeed{file: *file}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidearm
+
+import (
+	"application/core/model"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// requestTimeout is the timeout of the feed request
+const requestTimeout = 30 * time.Second
+
+// HTTPFeed loads the feed from the Sidearm server
+type HTTPFeed struct {
+	httpClient *http.Client
+}
+
+// LoadGames loads the games from the feed url configured in the env config, nil when there is no url
+func (f HTTPFeed) LoadGames(feedURL string) ([]model.SidearmGame, error) {
+	if len(feedURL) == 0 {
+		return nil, nil
+	}
+
+	resp, err := f.httpClient.Get(feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("error loading sidearm feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error loading sidearm feed: response code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading sidearm feed: %w", err)
+	}
+	return ParseFeed(data)
+}
+
+// NewHTTPFeed creates new Sidearm server feed
+func NewHTTPFeed() HTTPFeed {
+	return HTTPFeed{httpClient: &http.Client{Timeout: requestTimeout}}
+}
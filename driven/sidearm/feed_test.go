@@ -0,0 +1,154 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidearm
+
+import (
+	"application/core/model"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseFeed(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want []model.SidearmGame
+	}{
+		{name: "xml", file: "testdata/schedule.xml", want: []model.SidearmGame{
+			{ID: "41201", Sport: "Football", Opponent: "Duke", HomeAway: model.LegacyEventAthleticsHome, Venue: "Memorial Stadium",
+				City: "Champaign, Ill.", StartDate: time.Date(2025, 9, 6, 18, 30, 0, 0, chicago),
+				TicketURL: "https://fightingillini.com/tickets", GameURL: "https://fightingillini.com/sports/football/schedule/2025/duke"},
+			{ID: "41202", Sport: "Football", Opponent: "Rutgers", HomeAway: model.LegacyEventAthleticsAway, Venue: "SHI Stadium",
+				City: "Piscataway, N.J.", StartDate: time.Date(2025, 11, 15, 0, 0, 0, 0, chicago), AllDay: true},
+			{ID: "51310", Sport: "Men's Basketball", Opponent: "Missouri", HomeAway: model.LegacyEventAthleticsNeutral, Venue: "Enterprise Center",
+				City: "St. Louis, Mo.", StartDate: time.Date(2025, 12, 20, 19, 30, 0, 0, chicago), TicketURL: "https://fightingillini.com/braggin-rights"},
+		}},
+		{name: "json", file: "testdata/schedule.json", want: []model.SidearmGame{
+			{ID: "61077", Sport: "Women's Volleyball", Opponent: "Nebraska", HomeAway: model.LegacyEventAthleticsHome, Venue: "Huff Hall",
+				City: "Champaign, Ill.", StartDate: time.Date(2026, 2, 14, 19, 0, 0, 0, chicago),
+				GameURL: "https://fightingillini.com/sports/womens-volleyball/schedule/2026/nebraska"},
+			{ID: "61078", Sport: "Women's Volleyball", Opponent: "Purdue", HomeAway: model.LegacyEventAthleticsAway, Venue: "Holloway Gymnasium",
+				City: "West Lafayette, Ind.", StartDate: time.Date(2026, 2, 21, 13, 0, 0, 0, chicago)},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			games, err := ParseFeed(data)
+			if err != nil {
+				t.Fatalf("ParseFeed() error = %v", err)
+			}
+			if len(games) != len(tt.want) {
+				t.Fatalf("games count = %d, want %d", len(games), len(tt.want))
+			}
+			for i, game := range games {
+				want := tt.want[i]
+				if !game.StartDate.Equal(want.StartDate) {
+					t.Errorf("game %s StartDate = %v, want %v", game.ID, game.StartDate, want.StartDate)
+				}
+				game.StartDate = want.StartDate
+				if game != want {
+					t.Errorf("game = %+v, want %+v", game, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: "  \n"},
+		{name: "unknown format", data: "id,date\n1,9/6/2025"},
+		{name: "invalid xml", data: "<schedule><game>"},
+		{name: "invalid json", data: `{"games": [`},
+		{name: "invalid date", data: `[{"id": "1", "date": "next saturday", "time": "7 PM"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFeed([]byte(tt.data))
+			if err == nil {
+				t.Error("ParseFeed() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseGameDate(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		date       string
+		time       string
+		want       time.Time
+		wantAllDay bool
+		wantErr    bool
+	}{
+		{name: "US date with PM time", date: "10/4/2025", time: "2:30 PM", want: time.Date(2025, 10, 4, 14, 30, 0, 0, chicago)},
+		{name: "time without space", date: "10/4/2025", time: "11:00am", want: time.Date(2025, 10, 4, 11, 0, 0, 0, chicago)},
+		{name: "hour only", date: "2025-10-04", time: "7 PM", want: time.Date(2025, 10, 4, 19, 0, 0, 0, chicago)},
+		{name: "24 hours time", date: "2025-10-04", time: "19:05", want: time.Date(2025, 10, 4, 19, 5, 0, 0, chicago)},
+		{name: "central time zone", date: "2025-10-04", time: "7:00 PM CDT", want: time.Date(2025, 10, 4, 19, 0, 0, 0, chicago)},
+		{name: "standard time", date: "12/6/2025", time: "6 PM CST", want: time.Date(2025, 12, 6, 18, 0, 0, 0, chicago)},
+		{name: "date with time", date: "2025-10-04T13:00:00", time: "", want: time.Date(2025, 10, 4, 13, 0, 0, 0, chicago)},
+		{name: "to be announced", date: "10/4/2025", time: "TBA", want: time.Date(2025, 10, 4, 0, 0, 0, 0, chicago), wantAllDay: true},
+		{name: "all day", date: "2025-10-04", time: "All Day", want: time.Date(2025, 10, 4, 0, 0, 0, 0, chicago), wantAllDay: true},
+		{name: "invalid date", date: "Oct 4", time: "7 PM", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, allDay, err := parseGameDate(tt.date, tt.time, chicago)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGameDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Equal(tt.want) || allDay != tt.wantAllDay {
+				t.Errorf("parseGameDate() = %v, %v, want %v, %v", got, allDay, tt.want, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestFileFeed(t *testing.T) {
+	feed, err := NewFileFeed("testdata/schedule.json")
+	if err != nil {
+		t.Fatalf("NewFileFeed() error = %v", err)
+	}
+	//the saved feed is used whatever url is configured
+	games, err := feed.LoadGames("https://fightingillini.com/schedule.aspx")
+	if err != nil {
+		t.Fatalf("LoadGames() error = %v", err)
+	}
+	if len(games) != 2 {
+		t.Errorf("games count = %d, want 2", len(games))
+	}
+}
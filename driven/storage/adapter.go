@@ -100,6 +100,10 @@ func (a *Adapter) setCachedConfigs(configs []model.Config) {
 		switch config.Type {
 		case model.ConfigTypeEnv:
 			err = parseConfigsData[model.EnvConfigData](&config)
+		case model.ConfigTypeTPSModeration:
+			err = parseConfigsData[model.TPSModerationConfigData](&config)
+		case model.ConfigTypeBuildingEntrances:
+			err = parseConfigsData[model.BuildingEntrancesConfigData](&config)
 		default:
 			err = parseConfigsData[map[string]interface{}](&config)
 		}
@@ -280,8 +284,8 @@ func (a *Adapter) DeleteConfig(id string) error {
 }
 
 // FindLegacyEventItems finds legacy events items
-func (a *Adapter) FindLegacyEventItems(context TransactionContext, source *string, statuses *[]string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error) {
-	filter := bson.D{}
+func (a *Adapter) FindLegacyEventItems(context TransactionContext, orgID string, appID string, source *string, statuses *[]string, dataSourceEventID *string, calendarID *string, originatingCalendarID *string) ([]model.LegacyEventItem, error) {
+	filter := bson.D{primitive.E{Key: "org_id", Value: orgID}, primitive.E{Key: "app_id", Value: appID}}
 
 	//source
 	if source != nil {
@@ -318,8 +322,9 @@ func (a *Adapter) FindLegacyEventItems(context TransactionContext, source *strin
 }
 
 // FindLegacyEventItemsBySourceID finds legacy events items by source id
-func (a *Adapter) FindLegacyEventItemsBySourceID(context TransactionContext, sourceID string) ([]model.LegacyEventItem, error) {
-	filter := bson.D{primitive.E{Key: "item.sourceId", Value: sourceID}}
+func (a *Adapter) FindLegacyEventItemsBySourceID(context TransactionContext, orgID string, appID string, sourceID string) ([]model.LegacyEventItem, error) {
+	filter := bson.D{primitive.E{Key: "org_id", Value: orgID}, primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "item.sourceId", Value: sourceID}}
 	var data []model.LegacyEventItem
 	timeout := 15 * time.Second //15 seconds timeout
 	err := a.db.legacyEvents.FindWithParams(context, filter, &data, nil, &timeout)
@@ -330,6 +335,26 @@ func (a *Adapter) FindLegacyEventItemsBySourceID(context TransactionContext, sou
 	return data, nil
 }
 
+// FindExistingLegacyEventIDs gives which of the ids belong to legacy events of any org and app, with any status or archived
+func (a *Adapter) FindExistingLegacyEventIDs(ids []string) (map[string]bool, error) {
+	filter := bson.M{"item.id": bson.M{"$in": ids}}
+	findOptions := options.Find()
+	findOptions.SetProjection(bson.M{"item.id": 1})
+
+	result := map[string]bool{}
+	for _, collection := range []*collectionWrapper{a.db.legacyEvents, a.db.legacyEventsArchive} {
+		var list []model.LegacyEventItem
+		err := collection.Find(filter, &list, findOptions)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err)
+		}
+		for _, item := range list {
+			result[item.Item.ID] = true
+		}
+	}
+	return result, nil
+}
+
 // InsertLegacyEvents inserts legacy events
 func (a *Adapter) InsertLegacyEvents(context TransactionContext, items []model.LegacyEventItem) ([]model.LegacyEventItem, error) {
 
@@ -371,8 +396,10 @@ func (a *Adapter) DeleteLegacyEventsByIDs(context TransactionContext, Ids map[st
 }
 
 // DeleteLegacyEventsBySourceID deletes all legacy events by source id
-func (a *Adapter) DeleteLegacyEventsBySourceID(context TransactionContext, sourceID string) error {
+func (a *Adapter) DeleteLegacyEventsBySourceID(context TransactionContext, orgID string, appID string, sourceID string) error {
 	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "item.sourceId", Value: sourceID},
 	}
 	timeout := 15 * time.Second //15 seconds timeout
@@ -380,14 +407,72 @@ func (a *Adapter) DeleteLegacyEventsBySourceID(context TransactionContext, sourc
 	return err
 }
 
+// FindLegacyEventItemsByIDsAndCreator finds legacy events items by ids and creator, all the creator items for nil ids
+func (a *Adapter) FindLegacyEventItemsByIDsAndCreator(context TransactionContext, orgID string, appID string, ids []string, accountID string) ([]model.LegacyEventItem, error) {
+	filter := legacyEventsIDsAndCreatorFilter(orgID, appID, ids, accountID)
+
+	var list []model.LegacyEventItem
+	err := a.db.legacyEvents.FindWithContext(context, filter, &list, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	return list, nil
+}
+
+// FindPendingLegacyEventItems finds the legacy events items which wait for moderation, the oldest come first
+func (a *Adapter) FindPendingLegacyEventItems(context TransactionContext, orgID string, appID string, id *string) ([]model.LegacyEventItem, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "status.name", Value: model.LegacyEventStatusPending},
+	}
+	if id != nil {
+		filter = append(filter, primitive.E{Key: "item.id", Value: *id})
+	}
+
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "create_info.time", Value: 1}})
+
+	var list []model.LegacyEventItem
+	err := a.db.legacyEvents.FindWithContext(context, filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, &logutils.FieldArgs{"status": model.LegacyEventStatusPending}, err)
+	}
+	return list, nil
+}
+
+// UpdateLegacyEventModeration updates the status and the moderation of the legacy event item
+func (a *Adapter) UpdateLegacyEventModeration(context TransactionContext, item model.LegacyEventItem) error {
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "item.id": item.Item.ID}
+	update := bson.M{"$set": bson.M{
+		"status":     item.Status,
+		"moderation": item.Moderation,
+		"version":    item.Version,
+	}}
+
+	_, err := a.db.legacyEvents.UpdateOne(context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeLegacyEvents, filterArgs(filter), err)
+	}
+	return nil
+}
+
 // DeleteLegacyEventsByIDsAndCreator deletes legacy events by ids and creator
-func (a *Adapter) DeleteLegacyEventsByIDsAndCreator(context TransactionContext, ids []string, accountID string) error {
+func (a *Adapter) DeleteLegacyEventsByIDsAndCreator(context TransactionContext, orgID string, appID string, ids []string, accountID string) error {
+	filter := legacyEventsIDsAndCreatorFilter(orgID, appID, ids, accountID)
+
+	_, err := a.db.legacyEvents.DeleteManyWithContext(context, filter, nil)
+	return err
+}
+
+func legacyEventsIDsAndCreatorFilter(orgID string, appID string, ids []string, accountID string) bson.D {
 	var valueIds []string
 	for _, value := range ids {
 		valueIds = append(valueIds, value)
 	}
 
 	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "sync_process_source", Value: "events-tps-api"},
 		primitive.E{Key: "create_info.account_id", Value: accountID},
 	}
@@ -395,14 +480,12 @@ func (a *Adapter) DeleteLegacyEventsByIDsAndCreator(context TransactionContext,
 	if ids != nil {
 		filter = append(filter, primitive.E{Key: "item.id", Value: primitive.M{"$in": valueIds}})
 	}
-
-	_, err := a.db.legacyEvents.DeleteManyWithContext(context, filter, nil)
-	return err
+	return filter
 }
 
 // FindLegacyEvents finds legacy events by params
-func (a *Adapter) FindLegacyEvents(source *string, status *string) ([]model.LegacyEvent, error) {
-	filter := bson.D{}
+func (a *Adapter) FindLegacyEvents(orgID string, appID string, source *string, status *string) ([]model.LegacyEvent, error) {
+	filter := bson.D{primitive.E{Key: "org_id", Value: orgID}, primitive.E{Key: "app_id", Value: appID}}
 
 	//source
 	if source != nil {
@@ -432,40 +515,371 @@ func (a *Adapter) FindLegacyEvents(source *string, status *string) ([]model.Lega
 	return legacyEvents, err
 }
 
+// SearchLegacyEvents searches legacy events by text, the most relevant events come first
+func (a *Adapter) SearchLegacyEvents(query model.LegacyEventsQuery) ([]model.LegacyEvent, error) {
+	list, err := a.SearchLegacyEventItems(query)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyEvents := make([]model.LegacyEvent, len(list))
+	for i, l := range list {
+		legacyEvents[i] = l.Item
+	}
+	return legacyEvents, nil
+}
+
+// SearchLegacyEventItems searches legacy events items by text, the most relevant items come first
+func (a *Adapter) SearchLegacyEventItems(query model.LegacyEventsQuery) ([]model.LegacyEventItem, error) {
+	filter := legacyEventsQueryFilter(query)
+
+	findOptions := options.Find()
+	if len(query.Text) > 0 {
+		findOptions.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+		findOptions.SetSort(bson.D{primitive.E{Key: "score", Value: bson.M{"$meta": "textScore"}}, primitive.E{Key: "start_date", Value: 1}})
+	} else {
+		findOptions.SetSort(bson.D{primitive.E{Key: "start_date", Value: 1}})
+	}
+	if query.Limit > 0 {
+		findOptions.SetLimit(query.Limit)
+	}
+	if query.Offset > 0 {
+		findOptions.SetSkip(query.Offset)
+	}
+
+	var list []model.LegacyEventItem
+	timeout := 15 * time.Second //15 seconds timeout
+	err := a.db.legacyEvents.FindWithParams(nil, filter, &list, findOptions, &timeout)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err)
+	}
+	return list, nil
+}
+
+// FindLegacyEventsNear finds legacy events within radius meters of a point, the closest come first
+func (a *Adapter) FindLegacyEventsNear(latitude float64, longitude float64, radius float64, query model.LegacyEventsQuery) ([]model.NearbyLegacyEvent, error) {
+	//text search cannot be combined with geo near
+	query.Text = ""
+	filter := legacyEventsQueryFilter(query)
+
+	geoNear := bson.M{
+		"near":          model.GeoJSONPoint{Type: "Point", Coordinates: []float64{longitude, latitude}},
+		"distanceField": "distance",
+		"maxDistance":   radius,
+		"spherical":     true,
+		"key":           "geo_location",
+		"query":         filter,
+	}
+	pipeline := bson.A{bson.M{"$geoNear": geoNear}}
+	if query.Offset > 0 {
+		pipeline = append(pipeline, bson.M{"$skip": query.Offset})
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": query.Limit})
+	}
+
+	type nearbyItem struct {
+		Distance float64           `bson:"distance"`
+		Item     model.LegacyEvent `bson:"item"`
+	}
+	var list []nearbyItem
+	err := a.db.legacyEvents.Aggregate(nil, pipeline, &list, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err)
+	}
+
+	legacyEvents := make([]model.NearbyLegacyEvent, len(list))
+	for i, l := range list {
+		legacyEvents[i] = model.NearbyLegacyEvent{LegacyEvent: l.Item, Distance: l.Distance}
+	}
+	return legacyEvents, nil
+}
+
+// InsertLegacyEventsHistory inserts legacy events history records
+func (a *Adapter) InsertLegacyEventsHistory(context TransactionContext, items []model.LegacyEventHistoryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	storageItems := make([]interface{}, len(items))
+	for i, item := range items {
+		storageItems[i] = item
+	}
+
+	timeout := 15 * time.Second //15 seconds timeout
+	_, err := a.db.legacyEventsHistory.InsertManyWithParams(context, storageItems, nil, &timeout)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeLegacyEventHistory, nil, err)
+	}
+	return nil
+}
+
+// FindLegacyEventHistory finds the history of legacy event, the oldest versions come first
+func (a *Adapter) FindLegacyEventHistory(orgID string, appID string, eventID string) ([]model.LegacyEventHistoryItem, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "event_id": eventID}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "version", Value: 1}})
+
+	list := []model.LegacyEventHistoryItem{}
+	err := a.db.legacyEventsHistory.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEventHistory, filterArgs(filter), err)
+	}
+	return list, nil
+}
+
+// InsertWebToolsSyncReport inserts web tools sync report
+func (a *Adapter) InsertWebToolsSyncReport(report model.WebToolsSyncReport) error {
+	_, err := a.db.webToolsSyncReports.InsertOne(a.context, report)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeWebToolsSyncReport, &logutils.FieldArgs{"id": report.ID}, err)
+	}
+	return nil
+}
+
+// FindWebToolsSyncReports finds the web tools sync reports of the org and app, the most recent come first
+func (a *Adapter) FindWebToolsSyncReports(orgID string, appID string, limit int64) ([]model.WebToolsSyncReport, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "date_started", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+
+	list := []model.WebToolsSyncReport{}
+	err := a.db.webToolsSyncReports.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeWebToolsSyncReport, filterArgs(filter), err)
+	}
+	return list, nil
+}
+
+// ArchiveLegacyEvents moves the legacy events which ended before the date into the archive.
+// The archived events are purged once they have ended for purgeAfter. It gives the number of the archived events.
+func (a *Adapter) ArchiveLegacyEvents(context TransactionContext, endedBefore time.Time, purgeAfter time.Duration) (int, error) {
+	//the events without end date are archived by their start date
+	filter := bson.M{"$or": bson.A{
+		bson.M{"end_date": bson.M{"$lt": endedBefore}},
+		bson.M{"end_date": nil, "start_date": bson.M{"$lt": endedBefore}},
+	}}
+
+	var list []model.LegacyEventItem
+	timeout := 15 * time.Second //15 seconds timeout
+	err := a.db.legacyEvents.FindWithParams(context, filter, &list, nil, &timeout)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, filterArgs(filter), err)
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	ids := make([]string, len(list))
+	archiveItems := make([]interface{}, len(list))
+	for i, item := range list {
+		ended := item.StartDate
+		if item.EndDate != nil {
+			ended = item.EndDate
+		}
+		ids[i] = item.Item.ID
+		archiveItems[i] = model.ArchivedLegacyEventItem{LegacyEventItem: item, ArchivedAt: now, PurgeAt: ended.Add(purgeAfter)}
+	}
+	idsFilter := bson.M{"item.id": bson.M{"$in": ids}}
+
+	//replace the items archived before - the events ids are kept between the syncs
+	_, err = a.db.legacyEventsArchive.DeleteManyWithParams(context, idsFilter, nil, &timeout)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeLegacyEvents, &logutils.FieldArgs{"archive": true}, err)
+	}
+	_, err = a.db.legacyEventsArchive.InsertManyWithParams(context, archiveItems, nil, &timeout)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionInsert, model.TypeLegacyEvents, &logutils.FieldArgs{"archive": true}, err)
+	}
+
+	_, err = a.db.legacyEvents.DeleteManyWithParams(context, idsFilter, nil, &timeout)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeLegacyEvents, nil, err)
+	}
+	return len(list), nil
+}
+
+// SearchArchivedLegacyEvents searches the archived legacy events, the most relevant come first when searching by text
+func (a *Adapter) SearchArchivedLegacyEvents(query model.LegacyEventsQuery) ([]model.ArchivedLegacyEventItem, error) {
+	filter := legacyEventsQueryFilter(query)
+
+	findOptions := options.Find()
+	if len(query.Text) > 0 {
+		findOptions.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+		findOptions.SetSort(bson.D{primitive.E{Key: "score", Value: bson.M{"$meta": "textScore"}}, primitive.E{Key: "start_date", Value: -1}})
+	} else {
+		findOptions.SetSort(bson.D{primitive.E{Key: "start_date", Value: -1}})
+	}
+	if query.Limit > 0 {
+		findOptions.SetLimit(query.Limit)
+	}
+	if query.Offset > 0 {
+		findOptions.SetSkip(query.Offset)
+	}
+
+	var list []model.ArchivedLegacyEventItem
+	timeout := 15 * time.Second //15 seconds timeout
+	err := a.db.legacyEventsArchive.FindWithParams(nil, filter, &list, findOptions, &timeout)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, &logutils.FieldArgs{"archive": true}, err)
+	}
+	return list, nil
+}
+
+// legacyEventsQueryFilter constructs the legacy events filter for the query
+func legacyEventsQueryFilter(query model.LegacyEventsQuery) bson.D {
+	filter := bson.D{}
+
+	//text
+	if len(query.Text) > 0 {
+		filter = append(filter, primitive.E{Key: "$text", Value: bson.M{"$search": query.Text}})
+	}
+
+	//org and app
+	filter = append(filter, primitive.E{Key: "org_id", Value: query.OrgID}, primitive.E{Key: "app_id", Value: query.AppID})
+
+	//source
+	if query.Source != nil {
+		filter = append(filter, primitive.E{Key: "sync_process_source", Value: *query.Source})
+	}
+
+	//status
+	if query.Status != nil {
+		filter = append(filter, primitive.E{Key: "status.name", Value: *query.Status})
+	}
+
+	//creator
+	if query.CreatorAccountID != nil {
+		filter = append(filter, primitive.E{Key: "sync_process_source", Value: "events-tps-api"},
+			primitive.E{Key: "create_info.account_id", Value: *query.CreatorAccountID})
+	}
+
+	//categories
+	if len(query.Categories) > 0 {
+		filter = append(filter, primitive.E{Key: "item.category", Value: bson.M{"$in": query.Categories}})
+	}
+
+	//calendars
+	if len(query.CalendarIDs) > 0 {
+		filter = append(filter, primitive.E{Key: "item.calendarId", Value: bson.M{"$in": query.CalendarIDs}})
+	}
+
+	//originating calendars
+	if len(query.OriginatingCalendarIDs) > 0 {
+		filter = append(filter, primitive.E{Key: "item.originatingCalendarId", Value: bson.M{"$in": query.OriginatingCalendarIDs}})
+	}
+
+	//audiences
+	if len(query.Audiences) > 0 {
+		filter = append(filter, primitive.E{Key: "item.targetAudience", Value: bson.M{"$in": query.Audiences}})
+	}
+
+	//attendance modes
+	if len(query.AttendanceModes) > 0 {
+		filter = append(filter, primitive.E{Key: "item.attendanceMode", Value: bson.M{"$in": query.AttendanceModes}})
+	}
+
+	//free for the students - a free tier for the students or for everyone
+	if query.FreeForStudents {
+		filter = append(filter, primitive.E{Key: "item.costDetails.tiers", Value: bson.M{"$elemMatch": bson.M{
+			"amount":   0,
+			"audience": bson.M{"$in": bson.A{"", model.LegacyEventCostAudienceStudents}},
+		}}})
+	}
+
+	//period - the events which have not ended before "from" and have started before "to"
+	if query.From != nil {
+		filter = append(filter, primitive.E{Key: "$or", Value: bson.A{
+			bson.M{"end_date": bson.M{"$gte": *query.From}},
+			bson.M{"end_date": nil, "start_date": bson.M{"$gte": *query.From}},
+		}})
+	}
+	if query.To != nil {
+		filter = append(filter, primitive.E{Key: "start_date", Value: bson.M{"$lte": *query.To}})
+	}
+
+	return filter
+}
+
+// FindLegacyEventItemsWithoutQueryFields finds the legacy events items which do not have the query fields set
+func (a *Adapter) FindLegacyEventItemsWithoutQueryFields(context TransactionContext) ([]model.LegacyEventItem, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"start_date": bson.M{"$exists": false}},
+		bson.M{"geo_location": bson.M{"$exists": false}},
+		bson.M{"item.attendanceMode": bson.M{"$exists": false}},
+		bson.M{"item.costDetails": bson.M{"$exists": false}},
+		bson.M{"item.descriptionText": bson.M{"$exists": false}},
+	}}
+
+	var list []model.LegacyEventItem
+	timeout := 15 * time.Second //15 seconds timeout
+	err := a.db.legacyEvents.FindWithParams(context, filter, &list, nil, &timeout)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLegacyEvents, filterArgs(filter), err)
+	}
+	return list, nil
+}
+
+// UpdateLegacyEventItemQueryFields sets the query fields of a legacy event item
+func (a *Adapter) UpdateLegacyEventItemQueryFields(context TransactionContext, item model.LegacyEventItem) error {
+	filter := bson.M{"item.id": item.Item.ID}
+	update := bson.M{"$set": bson.M{
+		"start_date":          item.StartDate,
+		"end_date":            item.EndDate,
+		"geo_location":        item.GeoLocation,
+		"item.attendanceMode": item.Item.AttendanceMode,
+		"item.costDetails":    item.Item.CostDetails,
+		"item.isEventFree":    item.Item.IsEventFree,
+		//the descriptions stored before they were sanitized
+		"item.longDescription":     item.Item.LongDescription,
+		"item.descriptionText":     item.Item.DescriptionText,
+		"item.descriptionMarkdown": item.Item.DescriptionMarkdown,
+		"item.descriptionLinks":    item.Item.DescriptionLinks,
+	}}
+
+	_, err := a.db.legacyEvents.UpdateOne(context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeLegacyEvents, filterArgs(filter), err)
+	}
+	return nil
+}
+
 // AddWebtoolsBlacklistData update data from the database
-func (a *Adapter) AddWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error {
+func (a *Adapter) AddWebtoolsBlacklistData(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIDs []string) error {
+	//create the org and app blacklists if they do not exist
+	opts := options.Update().SetUpsert(true)
+
 	if dataSourceIDs != nil {
-		filterSource := bson.M{"name": "webtools_events_ids"}
+		filterSource := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_events_ids"}
 		updateSource := bson.M{
 			"$addToSet": bson.M{
 				"data": bson.M{"$each": dataSourceIDs},
 			},
 		}
 
-		_, err := a.db.webtoolsBlacklistItems.UpdateOne(a.context, filterSource, updateSource, nil)
+		_, err := a.db.webtoolsBlacklistItems.UpdateOne(a.context, filterSource, updateSource, opts)
 		if err != nil {
 			return errors.WrapErrorAction(logutils.ActionUpdate, "", filterArgs(filterSource), err)
 		}
 	}
 	if dataCalendarIDs != nil {
-		filterCalendar := bson.M{"name": "webtools_calendar_ids"}
+		filterCalendar := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_calendar_ids"}
 		updateCalendar := bson.M{
 			"$addToSet": bson.M{
 				"data": bson.M{"$each": dataCalendarIDs},
 			},
 		}
 
-		_, err := a.db.webtoolsBlacklistItems.UpdateOne(a.context, filterCalendar, updateCalendar, nil)
+		_, err := a.db.webtoolsBlacklistItems.UpdateOne(a.context, filterCalendar, updateCalendar, opts)
 		if err != nil {
 			return errors.WrapErrorAction(logutils.ActionUpdate, "", filterArgs(filterCalendar), err)
 		}
 	}
 
 	if dataOriginatingCalendarIDs != nil {
-		filterCalendar := bson.M{
-			"_id":  "3",
-			"name": "webtools_originating_calendar_ids",
-		}
+		filterCalendar := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_originating_calendar_ids"}
 
 		updateCalendar := bson.M{
 			"$addToSet": bson.M{
@@ -473,8 +887,6 @@ func (a *Adapter) AddWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarI
 			},
 		}
 
-		opts := options.Update().SetUpsert(true) //create webtools_originating_calendar_ids if it does not exist
-
 		_, err := a.db.webtoolsBlacklistItems.UpdateOne(a.context, filterCalendar, updateCalendar, opts)
 		if err != nil {
 			return errors.WrapErrorAction(logutils.ActionUpdate, "", filterArgs(filterCalendar), err)
@@ -487,9 +899,9 @@ func (a *Adapter) AddWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarI
 }
 
 // RemoveWebtoolsBlacklistData update data from the database
-func (a *Adapter) RemoveWebtoolsBlacklistData(dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIdsList []string) error {
+func (a *Adapter) RemoveWebtoolsBlacklistData(orgID string, appID string, dataSourceIDs []string, dataCalendarIDs []string, dataOriginatingCalendarIdsList []string) error {
 	if dataSourceIDs != nil {
-		filterSource := bson.M{"name": "webtools_events_ids"}
+		filterSource := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_events_ids"}
 		updateSource := bson.M{
 			"$pull": bson.M{
 				"data": bson.M{"$in": dataSourceIDs},
@@ -502,7 +914,7 @@ func (a *Adapter) RemoveWebtoolsBlacklistData(dataSourceIDs []string, dataCalend
 		}
 	}
 	if dataCalendarIDs != nil {
-		filterCalendar := bson.M{"name": "webtools_calendar_ids"}
+		filterCalendar := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_calendar_ids"}
 		updateCalendar := bson.M{
 			"$pull": bson.M{
 				"data": bson.M{"$in": dataCalendarIDs},
@@ -517,7 +929,7 @@ func (a *Adapter) RemoveWebtoolsBlacklistData(dataSourceIDs []string, dataCalend
 	}
 
 	if dataOriginatingCalendarIdsList != nil {
-		filterCalendar := bson.M{"name": "webtools_originating_calendar_ids"}
+		filterCalendar := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_originating_calendar_ids"}
 		updateCalendar := bson.M{
 			"$pull": bson.M{
 				"data": bson.M{"$in": dataOriginatingCalendarIdsList},
@@ -535,9 +947,9 @@ func (a *Adapter) RemoveWebtoolsBlacklistData(dataSourceIDs []string, dataCalend
 
 }
 
-// FindWebtoolsBlacklistData finds all webtools blacklist from the database
-func (a *Adapter) FindWebtoolsBlacklistData(context TransactionContext) ([]model.Blacklist, error) {
-	filterSource := bson.M{}
+// FindWebtoolsBlacklistData finds all webtools blacklist of the org and app from the database
+func (a *Adapter) FindWebtoolsBlacklistData(context TransactionContext, orgID string, appID string) ([]model.Blacklist, error) {
+	filterSource := bson.M{"org_id": orgID, "app_id": appID}
 	var dataSource []model.Blacklist
 	err := a.db.webtoolsBlacklistItems.FindWithContext(context, filterSource, &dataSource, nil)
 	if err != nil {
@@ -547,9 +959,9 @@ func (a *Adapter) FindWebtoolsBlacklistData(context TransactionContext) ([]model
 	return dataSource, nil
 }
 
-// FindWebtoolsOriginatingCalendarIDsBlacklistData finds all webtools blacklist from the database
-func (a *Adapter) FindWebtoolsOriginatingCalendarIDsBlacklistData() ([]model.Blacklist, error) {
-	filterSource := bson.M{"name": "webtools_originating_calendar_ids"}
+// FindWebtoolsOriginatingCalendarIDsBlacklistData finds the webtools originating calendars blacklist of the org and app from the database
+func (a *Adapter) FindWebtoolsOriginatingCalendarIDsBlacklistData(orgID string, appID string) ([]model.Blacklist, error) {
+	filterSource := bson.M{"org_id": orgID, "app_id": appID, "name": "webtools_originating_calendar_ids"}
 	var dataSource []model.Blacklist
 	err := a.db.webtoolsBlacklistItems.FindWithContext(a.context, filterSource, &dataSource, nil)
 	if err != nil {
@@ -559,6 +971,23 @@ func (a *Adapter) FindWebtoolsOriginatingCalendarIDsBlacklistData() ([]model.Bla
 	return dataSource, nil
 }
 
+// SetMissingLegacyEventsTenant sets the org and app of the legacy events, archived events, events history and webtools blacklists
+// stored before the events were scoped by org and app. It gives the number of the updated records.
+func (a *Adapter) SetMissingLegacyEventsTenant(orgID string, appID string) (int64, error) {
+	filter := bson.M{"org_id": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"org_id": orgID, "app_id": appID}}
+
+	var count int64
+	for _, collection := range []*collectionWrapper{a.db.legacyEvents, a.db.legacyEventsArchive, a.db.legacyEventsHistory, a.db.webtoolsBlacklistItems} {
+		result, err := collection.UpdateMany(a.context, filter, update, nil)
+		if err != nil {
+			return count, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeLegacyEvents, &logutils.FieldArgs{"collection": collection.coll.Name()}, err)
+		}
+		count += result.ModifiedCount
+	}
+	return count, nil
+}
+
 // PerformTransaction performs a transaction
 func (a *Adapter) PerformTransaction(transaction func(context TransactionContext) error, timeoutMilliSeconds int64) error {
 	// transaction
@@ -621,11 +1050,13 @@ func (a *Adapter) FindImageItems() ([]model.ContentImagesURL, error) {
 	return data, nil
 }
 
-// InsertImageItem insert content image url
-func (a *Adapter) InsertImageItem(items model.ContentImagesURL) error {
-	_, err := a.db.processedImages.InsertOne(nil, items)
+// SaveImageItem inserts or replaces content image urls
+func (a *Adapter) SaveImageItem(item model.ContentImagesURL) error {
+	filter := bson.M{"_id": item.ID}
+	opts := options.Replace().SetUpsert(true)
+	err := a.db.processedImages.ReplaceOneWithContext(nil, filter, item, opts)
 	if err != nil {
-		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeExample, nil, err)
+		return errors.WrapErrorAction(logutils.ActionSave, model.TypeImage, filterArgs(filter), err)
 	}
 	return nil
 }
@@ -671,6 +1102,9 @@ func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout stri
 type Listener interface {
 	OnConfigsUpdated()
 	OnExamplesUpdated()
+	OnBuildingFeaturesUpdated()
+	OnManagedFeatureLocationsUpdated()
+	OnBuildingAliasesUpdated()
 }
 
 // TransactionContext represents storage transaction interface
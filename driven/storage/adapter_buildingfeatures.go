@@ -20,6 +20,10 @@ import (
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // LoadAppBuildingFeatures loads all of the configured building features
@@ -37,3 +41,116 @@ func (a *Adapter) LoadAppBuildingFeatures() ([]model.AppBuildingFeature, error)
 
 	return data, nil
 }
+
+// InsertAppBuildingFeature inserts a building features catalog entry
+func (a *Adapter) InsertAppBuildingFeature(feature model.AppBuildingFeature) error {
+	_, err := a.db.appbuildingfeatures.InsertOne(a.context, feature)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.WrapErrorData(logutils.StatusFound, model.TypeAppBuildingFeature, &logutils.FieldArgs{"campus_code": feature.CampusCode}, err).SetStatus(string(logutils.StatusFound))
+	}
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeAppBuildingFeature, &logutils.FieldArgs{"campus_code": feature.CampusCode}, err)
+	}
+	return nil
+}
+
+// UpdateAppBuildingFeature updates the building features catalog entry with the campus code
+func (a *Adapter) UpdateAppBuildingFeature(feature model.AppBuildingFeature) error {
+	filter := bson.M{"campus_code": feature.CampusCode}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "campus_name", Value: feature.CampusName},
+			primitive.E{Key: "app_name", Value: feature.AppName},
+			primitive.E{Key: "app_code", Value: feature.AppCode},
+			primitive.E{Key: "show_in_app", Value: feature.ShowInApp},
+		}},
+	}
+
+	res, err := a.db.appbuildingfeatures.UpdateOne(a.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeAppBuildingFeature, filterArgs(filter), err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeAppBuildingFeature, filterArgs(filter)).SetStatus(string(logutils.StatusMissing))
+	}
+	return nil
+}
+
+// DeleteAppBuildingFeature deletes the building features catalog entry with the campus code
+func (a *Adapter) DeleteAppBuildingFeature(campusCode string) error {
+	filter := bson.M{"campus_code": campusCode}
+
+	res, err := a.db.appbuildingfeatures.DeleteOne(a.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeAppBuildingFeature, filterArgs(filter), err)
+	}
+	if res.DeletedCount != 1 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeAppBuildingFeature, filterArgs(filter)).SetStatus(string(logutils.StatusMissing))
+	}
+	return nil
+}
+
+// FindManagedFeatureLocations finds the managed feature locations of all the buildings or of the building with the number
+func (a *Adapter) FindManagedFeatureLocations(buildingNumber *string) ([]model.ManagedFeatureLocation, error) {
+	filter := bson.M{}
+	if buildingNumber != nil {
+		filter["building_number"] = *buildingNumber
+	}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "building_number", Value: 1}, primitive.E{Key: "key", Value: 1}})
+
+	list := []model.ManagedFeatureLocation{}
+	err := a.db.managedFeatureLocations.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeManagedFeatureLocation, filterArgs(filter), err)
+	}
+	return list, nil
+}
+
+// InsertManagedFeatureLocation inserts a managed feature location
+func (a *Adapter) InsertManagedFeatureLocation(location model.ManagedFeatureLocation) error {
+	_, err := a.db.managedFeatureLocations.InsertOne(a.context, location)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.WrapErrorData(logutils.StatusFound, model.TypeManagedFeatureLocation,
+			&logutils.FieldArgs{"building_number": location.BuildingNumber, "key": location.Key}, err).SetStatus(string(logutils.StatusFound))
+	}
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeManagedFeatureLocation,
+			&logutils.FieldArgs{"building_number": location.BuildingNumber, "key": location.Key}, err)
+	}
+	return nil
+}
+
+// UpdateManagedFeatureLocation updates a managed feature location
+func (a *Adapter) UpdateManagedFeatureLocation(location model.ManagedFeatureLocation) error {
+	filter := bson.M{"_id": location.ID}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "name", Value: location.Name},
+			primitive.E{Key: "floors", Value: location.Floors},
+			primitive.E{Key: "date_updated", Value: location.DateUpdated},
+		}},
+	}
+
+	res, err := a.db.managedFeatureLocations.UpdateOne(a.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeManagedFeatureLocation, filterArgs(filter), err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeManagedFeatureLocation, filterArgs(filter)).SetStatus(string(logutils.StatusMissing))
+	}
+	return nil
+}
+
+// DeleteManagedFeatureLocation deletes a managed feature location
+func (a *Adapter) DeleteManagedFeatureLocation(id string) error {
+	filter := bson.M{"_id": id}
+
+	res, err := a.db.managedFeatureLocations.DeleteOne(a.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeManagedFeatureLocation, filterArgs(filter), err)
+	}
+	if res.DeletedCount != 1 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeManagedFeatureLocation, filterArgs(filter)).SetStatus(string(logutils.StatusMissing))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindLatestBuildingsDataset finds the most recent version of the buildings dataset, nil if there is no dataset yet
+func (a *Adapter) FindLatestBuildingsDataset(context TransactionContext) (*model.BuildingsDataset, error) {
+	filter := bson.M{}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "version", Value: -1}}).SetLimit(1)
+
+	var list []model.BuildingsDataset
+	err := a.db.buildingsDatasets.FindWithContext(context, filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeBuildingsDataset, filterArgs(filter), err)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &list[0], nil
+}
+
+// InsertBuildingsDataset inserts a new version of the buildings dataset
+func (a *Adapter) InsertBuildingsDataset(context TransactionContext, dataset model.BuildingsDataset) error {
+	_, err := a.db.buildingsDatasets.InsertOne(context, dataset)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeBuildingsDataset, &logutils.FieldArgs{"id": dataset.ID, "version": dataset.Version}, err)
+	}
+	return nil
+}
+
+// UpdateBuildingsDatasetDateChecked sets the last time the buildings of the dataset have been confirmed by the building adapter
+func (a *Adapter) UpdateBuildingsDatasetDateChecked(context TransactionContext, id string, dateChecked time.Time) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{"date_checked": dateChecked}}
+
+	_, err := a.db.buildingsDatasets.UpdateOne(context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeBuildingsDataset, filterArgs(filter), err)
+	}
+	return nil
+}
+
+// DeleteBuildingsDatasetsBefore deletes the versions of the buildings dataset older than the version
+func (a *Adapter) DeleteBuildingsDatasetsBefore(context TransactionContext, version int) error {
+	filter := bson.M{"version": bson.M{"$lt": version}}
+
+	_, err := a.db.buildingsDatasets.DeleteManyWithContext(context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeBuildingsDataset, filterArgs(filter), err)
+	}
+	return nil
+}
+
+// FindBuildingAliases finds all the building aliases
+func (a *Adapter) FindBuildingAliases() ([]model.BuildingAlias, error) {
+	filter := bson.M{}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "alias", Value: 1}})
+
+	list := []model.BuildingAlias{}
+	err := a.db.buildingAliases.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeBuildingAlias, filterArgs(filter), err)
+	}
+	return list, nil
+}
+
+// InsertBuildingAlias inserts a building alias
+func (a *Adapter) InsertBuildingAlias(alias model.BuildingAlias) error {
+	_, err := a.db.buildingAliases.InsertOne(a.context, alias)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.WrapErrorData(logutils.StatusFound, model.TypeBuildingAlias, &logutils.FieldArgs{"alias": alias.Alias}, err).SetStatus(string(logutils.StatusFound))
+	}
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeBuildingAlias, &logutils.FieldArgs{"alias": alias.Alias}, err)
+	}
+	return nil
+}
+
+// DeleteBuildingAlias deletes a building alias
+func (a *Adapter) DeleteBuildingAlias(id string) error {
+	filter := bson.M{"_id": id}
+
+	res, err := a.db.buildingAliases.DeleteOne(a.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeBuildingAlias, filterArgs(filter), err)
+	}
+	if res.DeletedCount != 1 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeBuildingAlias, filterArgs(filter)).SetStatus(string(logutils.StatusMissing))
+	}
+	return nil
+}
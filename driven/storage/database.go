@@ -16,6 +16,7 @@ package storage
 
 import (
 	"context"
+	stderrors "errors"
 	"time"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
@@ -29,6 +30,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	//the mongo error codes of an index which exists with other options or keys
+	indexOptionsConflictCode  = 85
+	indexKeySpecsConflictCode = 86
+)
+
 type database struct {
 	mongoDBAuth  string
 	mongoDBName  string
@@ -38,16 +45,22 @@ type database struct {
 	dbClient *mongo.Client
 	logger   *logs.Logger
 
-	globalConfigs       *collectionWrapper
-	configs             *collectionWrapper
-	examples            *collectionWrapper
-	unitcalendars       *collectionWrapper
-	appbuildingfeatures *collectionWrapper
-	floorplanmarkup     *collectionWrapper
+	globalConfigs           *collectionWrapper
+	configs                 *collectionWrapper
+	examples                *collectionWrapper
+	unitcalendars           *collectionWrapper
+	appbuildingfeatures     *collectionWrapper
+	floorplanmarkup         *collectionWrapper
+	buildingsDatasets       *collectionWrapper
+	buildingAliases         *collectionWrapper
+	managedFeatureLocations *collectionWrapper
 
 	legacyEvents           *collectionWrapper
+	legacyEventsArchive    *collectionWrapper
+	legacyEventsHistory    *collectionWrapper
 	legacyLocations        *collectionWrapper
 	webtoolsBlacklistItems *collectionWrapper
+	webToolsSyncReports    *collectionWrapper
 	processedImages        *collectionWrapper
 
 	listeners []Listener
@@ -101,9 +114,28 @@ func (d *database) start() error {
 		return err
 	}
 
+	legacyEventsArchive := &collectionWrapper{database: d, coll: db.Collection("legacy_events_archive")}
+	err = d.applyLegacyEventsArchiveChecks(legacyEventsArchive)
+	if err != nil {
+		return err
+	}
+
+	//the changed values are read as maps instead of key-value lists
+	historyOptions := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+	legacyEventsHistory := &collectionWrapper{database: d, coll: db.Collection("legacy_events_history", historyOptions)}
+	err = d.applyLegacyEventsHistoryChecks(legacyEventsHistory)
+	if err != nil {
+		return err
+	}
+
 	unitcalendars := &collectionWrapper{database: d, coll: db.Collection("unitcalendars")}
 
 	appbuildingfeatures := &collectionWrapper{database: d, coll: db.Collection("building_features")}
+	err = d.applyBuildingFeaturesChecks(appbuildingfeatures)
+	if err != nil {
+		return err
+	}
+
 	floorplanmarkup := &collectionWrapper{database: d, coll: db.Collection("floorplan_markup")}
 
 	legacyLocations := &collectionWrapper{database: d, coll: db.Collection("legacy_locations")}
@@ -118,12 +150,36 @@ func (d *database) start() error {
 		return err
 	}
 
+	webToolsSyncReports := &collectionWrapper{database: d, coll: db.Collection("webtools_sync_reports")}
+	err = d.applyWebToolsSyncReportsChecks(webToolsSyncReports)
+	if err != nil {
+		return err
+	}
+
 	processedImages := &collectionWrapper{database: d, coll: db.Collection("processed_images")}
 	err = d.applyprocessedImagesChecks(processedImages)
 	if err != nil {
 		return err
 	}
 
+	buildingsDatasets := &collectionWrapper{database: d, coll: db.Collection("buildings_datasets")}
+	err = d.applyBuildingsDatasetsChecks(buildingsDatasets)
+	if err != nil {
+		return err
+	}
+
+	buildingAliases := &collectionWrapper{database: d, coll: db.Collection("building_aliases")}
+	err = d.applyBuildingAliasesChecks(buildingAliases)
+	if err != nil {
+		return err
+	}
+
+	managedFeatureLocations := &collectionWrapper{database: d, coll: db.Collection("managed_feature_locations")}
+	err = d.applyManagedFeatureLocationsChecks(managedFeatureLocations)
+	if err != nil {
+		return err
+	}
+
 	//assign the db, db client and the collections
 	d.db = db
 	d.dbClient = client
@@ -132,14 +188,23 @@ func (d *database) start() error {
 	d.configs = configs
 	d.examples = examples
 	d.legacyEvents = legacyEvents
+	d.legacyEventsArchive = legacyEventsArchive
+	d.legacyEventsHistory = legacyEventsHistory
 	d.unitcalendars = unitcalendars
 	d.appbuildingfeatures = appbuildingfeatures
 	d.floorplanmarkup = floorplanmarkup
 	d.legacyLocations = legacyLocations
 	d.webtoolsBlacklistItems = webtoolsBlacklistItems
+	d.webToolsSyncReports = webToolsSyncReports
 	d.processedImages = processedImages
+	d.buildingsDatasets = buildingsDatasets
+	d.buildingAliases = buildingAliases
+	d.managedFeatureLocations = managedFeatureLocations
 
 	go d.configs.Watch(nil, d.logger)
+	go d.appbuildingfeatures.Watch(nil, d.logger)
+	go d.managedFeatureLocations.Watch(nil, d.logger)
+	go d.buildingAliases.Watch(nil, d.logger)
 
 	return nil
 }
@@ -184,8 +249,14 @@ func (d *database) applyExamplesChecks(examples *collectionWrapper) error {
 func (d *database) applyLegacyEventsChecks(legacyEvents *collectionWrapper) error {
 	d.logger.Info("apply legacy events checks.....")
 
+	//org id + app id
+	err := legacyEvents.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
 	//sync process source
-	err := legacyEvents.AddIndex(bson.D{primitive.E{Key: "sync_process_source", Value: 1}}, false)
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "sync_process_source", Value: 1}}, false)
 	if err != nil {
 		return err
 	}
@@ -220,10 +291,162 @@ func (d *database) applyLegacyEventsChecks(legacyEvents *collectionWrapper) erro
 		return err
 	}
 
+	//start date
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "start_date", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//category
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "item.category", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//attendance mode
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "item.attendanceMode", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//cost tiers
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "item.costDetails.tiers.amount", Value: 1}, primitive.E{Key: "item.costDetails.tiers.audience", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//geo location
+	err = legacyEvents.AddIndex(bson.D{primitive.E{Key: "geo_location", Value: "2dsphere"}}, false)
+	if err != nil {
+		return err
+	}
+
+	//text search
+	err = d.applyLegacyEventsTextIndex(legacyEvents)
+	if err != nil {
+		return err
+	}
+
 	d.logger.Info("legacy events passed")
 	return nil
 }
 
+func (d *database) applyLegacyEventsArchiveChecks(legacyEventsArchive *collectionWrapper) error {
+	d.logger.Info("apply legacy events archive checks.....")
+
+	//id
+	err := legacyEventsArchive.AddIndex(bson.D{primitive.E{Key: "item.id", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	//org id + app id
+	err = legacyEventsArchive.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//sync process source
+	err = legacyEventsArchive.AddIndex(bson.D{primitive.E{Key: "sync_process_source", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//start date
+	err = legacyEventsArchive.AddIndex(bson.D{primitive.E{Key: "start_date", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//category
+	err = legacyEventsArchive.AddIndex(bson.D{primitive.E{Key: "item.category", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//purge - the items are removed once the purge date passes
+	err = legacyEventsArchive.AddIndexWithOptions(bson.D{primitive.E{Key: "purge_at", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+	if err != nil {
+		return err
+	}
+
+	//text search
+	err = d.applyLegacyEventsTextIndex(legacyEventsArchive)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("legacy events archive passed")
+	return nil
+}
+
+func (d *database) applyLegacyEventsHistoryChecks(legacyEventsHistory *collectionWrapper) error {
+	d.logger.Info("apply legacy events history checks.....")
+
+	//event id + version
+	err := legacyEventsHistory.AddIndex(bson.D{primitive.E{Key: "event_id", Value: 1}, primitive.E{Key: "version", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	//org id + app id
+	err = legacyEventsHistory.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("legacy events history passed")
+	return nil
+}
+
+// applyLegacyEventsTextIndex creates the text index used for searching the events.
+// A collection can have only one text index so it is recreated when the searched fields change.
+func (d *database) applyLegacyEventsTextIndex(legacyEvents *collectionWrapper) error {
+	name := "legacy_events_text"
+	keys := bson.D{
+		primitive.E{Key: "item.title", Value: "text"},
+		primitive.E{Key: "item.longDescription", Value: "text"},
+		primitive.E{Key: "item.sponsor", Value: "text"},
+		primitive.E{Key: "item.speaker", Value: "text"},
+		primitive.E{Key: "item.location.description", Value: "text"},
+		primitive.E{Key: "item.tags", Value: "text"},
+	}
+	weights := bson.D{
+		primitive.E{Key: "item.title", Value: 10},
+		primitive.E{Key: "item.tags", Value: 5},
+		primitive.E{Key: "item.speaker", Value: 3},
+		primitive.E{Key: "item.sponsor", Value: 3},
+		primitive.E{Key: "item.location.description", Value: 2},
+		primitive.E{Key: "item.longDescription", Value: 1},
+	}
+	opts := options.Index().SetName(name).SetWeights(weights).SetDefaultLanguage("english")
+
+	err := legacyEvents.AddIndexWithOptions(keys, opts)
+	if err == nil {
+		return nil
+	}
+	//a collection has one text index, it is recreated only when it was created with other fields or options
+	var serverErr mongo.ServerError
+	if !stderrors.As(err, &serverErr) || !(serverErr.HasErrorCode(indexOptionsConflictCode) || serverErr.HasErrorCode(indexKeySpecsConflictCode)) {
+		return err
+	}
+
+	d.logger.Infof("recreating the legacy events text index - %s", err)
+	indexes, err := legacyEvents.ListIndexes(nil, d.logger)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if _, ok := index["textIndexVersion"]; ok {
+			err = legacyEvents.DropIndex(nil, index["name"].(string))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return legacyEvents.AddIndexWithOptions(keys, opts)
+}
+
 func (d *database) applyLegacyLocationsChecks(locations *collectionWrapper) error {
 	d.logger.Info("apply legacy_locations checks.....")
 
@@ -239,10 +462,29 @@ func (d *database) applyLegacyLocationsChecks(locations *collectionWrapper) erro
 func (d *database) applyWebtoolsBlacklistItemsChecks(webtoolsBlacklistItems *collectionWrapper) error {
 	d.logger.Info("apply webtools_blacklist_items checks.....")
 
+	//org id + app id + name
+	err := webtoolsBlacklistItems.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "name", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
 	d.logger.Info("legacy webtools_blacklist_items passed")
 	return nil
 }
 
+func (d *database) applyWebToolsSyncReportsChecks(webToolsSyncReports *collectionWrapper) error {
+	d.logger.Info("apply webtools_sync_reports checks.....")
+
+	//org id + app id + date started
+	err := webToolsSyncReports.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "date_started", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("webtools_sync_reports passed")
+	return nil
+}
+
 func (d *database) applyprocessedImagesChecks(webtoolsBlacklistItems *collectionWrapper) error {
 	d.logger.Info("apply processed_images checks.....")
 
@@ -250,6 +492,95 @@ func (d *database) applyprocessedImagesChecks(webtoolsBlacklistItems *collection
 	return nil
 }
 
+func (d *database) applyBuildingsDatasetsChecks(buildingsDatasets *collectionWrapper) error {
+	d.logger.Info("apply buildings_datasets checks.....")
+
+	//version, only one dataset is stored per version
+	err := buildingsDatasets.AddIndex(bson.D{primitive.E{Key: "version", Value: -1}}, true)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("buildings_datasets passed")
+	return nil
+}
+
+func (d *database) applyBuildingAliasesChecks(buildingAliases *collectionWrapper) error {
+	d.logger.Info("apply building_aliases checks.....")
+
+	//alias, an alias names only one building
+	err := buildingAliases.AddIndex(bson.D{primitive.E{Key: "alias", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("building_aliases passed")
+	return nil
+}
+
+func (d *database) applyBuildingFeaturesChecks(buildingFeatures *collectionWrapper) error {
+	d.logger.Info("apply building_features checks.....")
+
+	//the catalog has been maintained by hand, the duplicate campus codes must be removed before the unique index is created
+	err := d.removeDuplicateBuildingFeatures(buildingFeatures)
+	if err != nil {
+		return err
+	}
+
+	//campus code, the catalog has one entry per feature of the wayfinding data
+	err = buildingFeatures.AddIndex(bson.D{primitive.E{Key: "campus_code", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("building_features passed")
+	return nil
+}
+
+// removeDuplicateBuildingFeatures keeps the last catalog entry of each campus code, the one the loaded catalog used, and logs the removed ones
+func (d *database) removeDuplicateBuildingFeatures(buildingFeatures *collectionWrapper) error {
+	pipeline := bson.A{
+		bson.M{"$group": bson.M{"_id": "$campus_code", "entries": bson.M{"$push": "$$ROOT"}, "count": bson.M{"$sum": 1}}},
+		bson.M{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}
+	var duplicates []struct {
+		CampusCode string   `bson:"_id"`
+		Entries    []bson.M `bson:"entries"`
+	}
+	err := buildingFeatures.Aggregate(context.Background(), pipeline, &duplicates, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, duplicate := range duplicates {
+		kept := duplicate.Entries[len(duplicate.Entries)-1]
+		removed := duplicate.Entries[:len(duplicate.Entries)-1]
+		ids := make(bson.A, len(removed))
+		for i, entry := range removed {
+			ids[i] = entry["_id"]
+		}
+		_, err = buildingFeatures.DeleteMany(bson.M{"_id": bson.M{"$in": ids}}, nil)
+		if err != nil {
+			return err
+		}
+		d.logger.Warnf("removed %d duplicate building_features entries of campus code %q - kept %v, removed %v", len(removed), duplicate.CampusCode, kept, removed)
+	}
+	return nil
+}
+
+func (d *database) applyManagedFeatureLocationsChecks(managedFeatureLocations *collectionWrapper) error {
+	d.logger.Info("apply managed_feature_locations checks.....")
+
+	//building number and key, a building has one location per feature
+	err := managedFeatureLocations.AddIndex(bson.D{primitive.E{Key: "building_number", Value: 1}, primitive.E{Key: "key", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("managed_feature_locations passed")
+	return nil
+}
+
 func (d *database) onDataChanged(changeDoc map[string]interface{}) {
 	if changeDoc == nil {
 		return
@@ -275,5 +606,23 @@ func (d *database) onDataChanged(changeDoc map[string]interface{}) {
 		for _, listener := range d.listeners {
 			go listener.OnExamplesUpdated()
 		}
+	case "building_features":
+		d.logger.Info("building_features collection changed")
+
+		for _, listener := range d.listeners {
+			go listener.OnBuildingFeaturesUpdated()
+		}
+	case "managed_feature_locations":
+		d.logger.Info("managed_feature_locations collection changed")
+
+		for _, listener := range d.listeners {
+			go listener.OnManagedFeatureLocationsUpdated()
+		}
+	case "building_aliases":
+		d.logger.Info("building_aliases collection changed")
+
+		for _, listener := range d.listeners {
+			go listener.OnBuildingAliasesUpdated()
+		}
 	}
 }
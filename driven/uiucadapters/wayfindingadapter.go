@@ -17,6 +17,7 @@ package uiucadapters
 import (
 	model "application/core/model"
 	uiuc "application/core/model/uiuc"
+	"application/utils"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -28,16 +29,17 @@ import (
 
 // UIUCWayFinding is a vendor specific structure that implements the BuildingLocation interface
 type UIUCWayFinding struct {
-	KnownBuildingFeatures *map[string]model.AppBuildingFeature
+	KnownBuildingFeatures func() map[string]model.AppBuildingFeature //gives the current building features catalog by campus code
 }
 
 // NewUIUCWayFinding returns a new instance of a UIUCWayFinding struct
-func NewUIUCWayFinding(knownfeatures *map[string]model.AppBuildingFeature) *UIUCWayFinding {
+func NewUIUCWayFinding(knownfeatures func() map[string]model.AppBuildingFeature) *UIUCWayFinding {
 	return &UIUCWayFinding{KnownBuildingFeatures: knownfeatures}
 }
 
-// GetEntrance returns the active entrance closest to the user's position that meets the ADA Accessibility filter requirement
-func (uwf *UIUCWayFinding) GetEntrance(bldgID string, adaAccessibleOnly bool, latitude float64, longitude float64, conf *model.EnvConfigData) (*model.Entrance, error) {
+// GetEntrance returns the active entrance closest to the user's position that meets the ADA Accessibility filter requirement.
+// When the user's position is unknown the building's primary entrance is returned.
+func (uwf *UIUCWayFinding) GetEntrance(bldgID string, adaAccessibleOnly bool, latitude float64, longitude float64, primaryEntranceID string, conf *model.EnvConfigData) (*model.NearestEntrance, error) {
 	apiURL := conf.WayFindingURL
 	apikey := conf.WayFindingKey
 
@@ -55,14 +57,12 @@ func (uwf *UIUCWayFinding) GetEntrance(bldgID string, adaAccessibleOnly bool, la
 
 	bldg, err := uwf.getBuildingData(url, apikey, query, parameters, false)
 	if err != nil {
-		ent := model.Entrance{}
-		return &ent, err
+		return nil, err
 	}
-	ent := uwf.closestEntrance((*bldg)[0])
-	if ent != nil {
-		return uiuc.NewEntrance(*ent), nil
+	if len(*bldg) == 0 {
+		return nil, nil
 	}
-	return nil, nil
+	return uwf.closestEntrance((*bldg)[0], adaAccessibleOnly, latitude, longitude, primaryEntranceID), nil
 }
 
 // GetBuildings returns a list of all buildings
@@ -77,7 +77,8 @@ func (uwf *UIUCWayFinding) GetBuildings(conf *model.EnvConfigData) (*[]model.Bui
 	if err != nil {
 		return nil, err
 	}
-	returnList := uiuc.NewBuildingList(cmpBldgs, uwf.KnownBuildingFeatures)
+	knownFeatures := uwf.KnownBuildingFeatures()
+	returnList := uiuc.NewBuildingList(cmpBldgs, &knownFeatures)
 	return returnList, nil
 }
 
@@ -108,7 +109,8 @@ func (uwf *UIUCWayFinding) GetBuilding(bldgID string, adaAccessibleOnly bool, la
 		bldg := model.Building{}
 		return &bldg, err
 	}
-	return uiuc.NewBuilding((*cmpBldg)[0], uwf.KnownBuildingFeatures), nil
+	knownFeatures := uwf.KnownBuildingFeatures()
+	return uiuc.NewBuilding((*cmpBldg)[0], &knownFeatures), nil
 }
 
 // GetFloorPlan returns the requested floor plan
@@ -138,16 +140,41 @@ func (uwf *UIUCWayFinding) GetFloorPlan(bldgNum string, floornumber string, mark
 	return uiuc.NewFloorPlan(*uiucfp, markup), nil
 }
 
-// the entrance list coming back from a ranged query to the API is sorted closest to farthest from
-// the user's coordinates. The first entrance in the list that is active and matches the ADA filter
-// will be the one to return
-func (uwf *UIUCWayFinding) closestEntrance(bldg uiuc.CampusBuilding) *uiuc.CampusEntrance {
+// closestEntrance gives the active entrance matching the ADA filter with the shortest distance to the user's position.
+// When the position is unknown it falls back to the primary entrance, or to the first matching entrance
+// when the primary one is not configured or does not match the filter
+func (uwf *UIUCWayFinding) closestEntrance(bldg uiuc.CampusBuilding, adaAccessibleOnly bool, latitude float64, longitude float64, primaryEntranceID string) *model.NearestEntrance {
+	candidates := []uiuc.CampusEntrance{}
 	for _, n := range bldg.Entrances {
-		if n.Available {
-			return &n
+		if n.Available && (!adaAccessibleOnly || n.ADACompliant) {
+			candidates = append(candidates, n)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if latitude == 0 && longitude == 0 {
+		for _, n := range candidates {
+			if len(primaryEntranceID) > 0 && n.UUID == primaryEntranceID {
+				return &model.NearestEntrance{Entrance: *uiuc.NewEntrance(n), Primary: true}
+			}
+		}
+		return &model.NearestEntrance{Entrance: *uiuc.NewEntrance(candidates[0])}
+	}
+
+	closest := 0
+	closestDistance := -1.0
+	for i, n := range candidates {
+		distance := utils.GreatCircleDistance(latitude, longitude, n.Latitude, n.Longitude)
+		if closestDistance < 0 || distance < closestDistance {
+			closest = i
+			closestDistance = distance
 		}
 	}
-	return nil
+	entrance := candidates[closest]
+	bearing := utils.InitialBearing(latitude, longitude, entrance.Latitude, entrance.Longitude)
+	return &model.NearestEntrance{Entrance: *uiuc.NewEntrance(entrance), Distance: &closestDistance, Bearing: &bearing, Primary: entrance.UUID == primaryEntranceID}
 }
 
 func (uwf *UIUCWayFinding) getBuildingData(targetURL string, apikey string, queryString string, parameters string, allBuildings bool) (*[]uiuc.CampusBuilding, error) {
@@ -0,0 +1,148 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walkingpaths
+
+import (
+	"application/core/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// featureCollection is a GeoJSON feature collection, like an OSM extract converted by osmtogeojson or ogr2ogr
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature is a GeoJSON feature
+type feature struct {
+	ID         interface{}            `json:"id"`
+	Geometry   *geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geometry is a GeoJSON geometry, only the line strings are used
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParsePaths parses the paths of a GeoJSON feature collection. The LineString and MultiLineString features are the paths,
+// the ones closed to pedestrians by the OSM foot or access tags are skipped and the highway=steps or wheelchair=no ones are stairs
+func ParsePaths(data []byte) ([]model.WalkingPath, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty walking paths")
+	}
+
+	var collection featureCollection
+	err := json.Unmarshal(data, &collection)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing walking paths: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("walking paths are a %s instead of a FeatureCollection", collection.Type)
+	}
+
+	paths := []model.WalkingPath{}
+	for i, f := range collection.Features {
+		if f.Geometry == nil || !isWalkable(f.Properties) {
+			continue
+		}
+
+		var lines [][][]float64
+		switch f.Geometry.Type {
+		case "LineString":
+			var line [][]float64
+			err = json.Unmarshal(f.Geometry.Coordinates, &line)
+			lines = [][][]float64{line}
+		case "MultiLineString":
+			err = json.Unmarshal(f.Geometry.Coordinates, &lines)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the coordinates of walking path %d: %w", i, err)
+		}
+
+		id := featureID(f, i)
+		name := stringProperty(f.Properties, "name")
+		stairs := stringProperty(f.Properties, "highway") == "steps" || stringProperty(f.Properties, "wheelchair") == "no"
+		for j, line := range lines {
+			coordinates := make([][]float64, 0, len(line))
+			for _, position := range line {
+				if len(position) < 2 {
+					return nil, fmt.Errorf("invalid position in walking path %s", id)
+				}
+				coordinates = append(coordinates, []float64{position[0], position[1]})
+			}
+			if len(coordinates) < 2 {
+				continue
+			}
+
+			pathID := id
+			if len(lines) > 1 {
+				pathID = fmt.Sprintf("%s-%d", id, j)
+			}
+			paths = append(paths, model.WalkingPath{ID: pathID, Name: name, Coordinates: coordinates, Stairs: stairs})
+		}
+	}
+	return paths, nil
+}
+
+// isWalkable tells if the pedestrians may use the path
+func isWalkable(properties map[string]interface{}) bool {
+	switch stringProperty(properties, "foot") {
+	case "no", "private":
+		return false
+	case "yes", "designated", "permissive":
+		return true
+	}
+	switch stringProperty(properties, "access") {
+	case "no", "private":
+		return false
+	}
+	return true
+}
+
+// featureID gives the feature id, the OSM id of the way or the position of the feature when it has none
+func featureID(f feature, index int) string {
+	switch id := f.ID.(type) {
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%.0f", id)
+	}
+	for _, key := range []string{"@id", "id", "osm_id"} {
+		if id := stringProperty(f.Properties, key); len(id) > 0 {
+			return id
+		}
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// stringProperty gives the feature property as a string, empty when it is missing
+func stringProperty(properties map[string]interface{}, key string) string {
+	switch value := properties[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
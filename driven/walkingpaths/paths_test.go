@@ -0,0 +1,81 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walkingpaths
+
+import (
+	"application/core/model"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParsePaths(t *testing.T) {
+	data, err := os.ReadFile("testdata/paths.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := ParsePaths(data)
+	if err != nil {
+		t.Fatalf("ParsePaths() error = %v", err)
+	}
+
+	want := []model.WalkingPath{
+		{ID: "way/1", Name: "Green Street", Coordinates: [][]float64{{-88.230, 40.110}, {-88.228, 40.110}, {-88.226, 40.110}}},
+		{ID: "way/2", Coordinates: [][]float64{{-88.228, 40.110}, {-88.228, 40.111}}, Stairs: true},
+		{ID: "3-0", Name: "Quad Ramp", Coordinates: [][]float64{{-88.230, 40.110}, {-88.230, 40.111}}},
+		{ID: "3-1", Name: "Quad Ramp", Coordinates: [][]float64{{-88.230, 40.111}, {-88.228, 40.111}}},
+		{ID: "4", Coordinates: [][]float64{{-88.226, 40.110}, {-88.226, 40.109}}, Stairs: true},
+		{ID: "4", Coordinates: [][]float64{{-88.226, 40.109}, {-88.225, 40.109}}},
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("ParsePaths() = %+v\nwant %+v", paths, want)
+	}
+}
+
+func TestParsePathsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: " "},
+		{name: "not json", data: "<osm></osm>"},
+		{name: "not a feature collection", data: `{"type": "Feature", "geometry": null}`},
+		{name: "invalid coordinates", data: `{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [-88.23, 40.11]}}]}`},
+		{name: "invalid position", data: `{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[-88.23, 40.11], [-88.22]]}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePaths([]byte(tt.data))
+			if err == nil {
+				t.Error("ParsePaths() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseShippedPaths(t *testing.T) {
+	//the shipped extract is an empty placeholder, it parses without paths
+	data, err := os.ReadFile("../../assets/walking_paths.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := ParsePaths(data)
+	if err != nil || len(paths) != 0 {
+		t.Errorf("ParsePaths() of the shipped extract = %d paths, %v", len(paths), err)
+	}
+}
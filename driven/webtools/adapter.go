@@ -0,0 +1,28 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webtools
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// DefaultFeedURL is the web tools events feed used by the app
+const DefaultFeedURL = "https://xml.calendars.illinois.edu/eventXML17/6991.xml"
+
+// pageFilePath gives the path of the saved feed page in the directory
+func pageFilePath(dir string, page int) string {
+	return filepath.Join(dir, fmt.Sprintf("page-%04d.xml", page))
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webtools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DirectoryFeed loads the feed pages saved in a local directory, for example by the recorder.
+// It allows running the sync offline and replaying real feeds.
+type DirectoryFeed struct {
+	dir string
+}
+
+// LoadPage loads the saved feed page
+func (f DirectoryFeed) LoadPage(page int) ([]byte, error) {
+	data, err := os.ReadFile(pageFilePath(f.dir, page))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading web tools page %d: %w", page, err)
+	}
+	return data, nil
+}
+
+// NewDirectoryFeed creates new feed of the pages saved in the directory
+func NewDirectoryFeed(dir string) (*DirectoryFeed, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error opening web tools feed directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("web tools feed path %s is not a directory", dir)
+	}
+	return &DirectoryFeed{dir: dir}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webtools
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	//requestTimeout is the timeout of a feed page request
+	requestTimeout = 30 * time.Second
+	//requestRetries is the number of the retries of a failed feed page request
+	requestRetries = 3
+	//retryBackoff is the wait before the first retry, it doubles on every next retry
+	retryBackoff = 2 * time.Second
+)
+
+// HTTPFeed loads the feed pages from the web tools server
+type HTTPFeed struct {
+	feedURL    string
+	httpClient *http.Client
+}
+
+// LoadPage loads the feed page, the failed requests are retried with backoff
+func (f HTTPFeed) LoadPage(page int) ([]byte, error) {
+	pageURL, err := url.Parse(f.feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing web tools feed url: %w", err)
+	}
+	query := pageURL.Query()
+	query.Set("pageNumber", strconv.Itoa(page))
+	pageURL.RawQuery = query.Encode()
+
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		data, retry, err := f.loadURL(pageURL.String())
+		if err == nil {
+			return data, nil
+		}
+		if !retry || attempt == requestRetries {
+			return nil, fmt.Errorf("error loading web tools page %d: %w", page, err)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// loadURL loads the data, it tells if the failed request could succeed when retried
+func (f HTTPFeed) loadURL(pageURL string) ([]byte, bool, error) {
+	resp, err := f.httpClient.Get(pageURL)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return nil, retry, fmt.Errorf("response code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	return data, false, nil
+}
+
+// NewHTTPFeed creates new web tools server feed, the default feed is used for empty url
+func NewHTTPFeed(feedURL string) HTTPFeed {
+	if len(feedURL) == 0 {
+		feedURL = DefaultFeedURL
+	}
+	return HTTPFeed{feedURL: feedURL, httpClient: &http.Client{Timeout: requestTimeout}}
+}
@@ -0,0 +1,167 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webtools
+
+import (
+	"application/core/model"
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+const fixtureDir = "testdata/feed"
+
+func TestDirectoryFeed(t *testing.T) {
+	feed, err := NewDirectoryFeed(fixtureDir)
+	if err != nil {
+		t.Fatalf("NewDirectoryFeed() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		page       int
+		wantEvents []string
+	}{
+		{name: "first page", page: 0, wantEvents: []string{"33560001", "33560002"}},
+		{name: "last page", page: 1, wantEvents: []string{"33560003"}},
+		{name: "after the last page", page: 2, wantEvents: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := feed.LoadPage(tt.page)
+			if err != nil {
+				t.Fatalf("LoadPage() error = %v", err)
+			}
+			if tt.wantEvents == nil {
+				if data != nil {
+					t.Fatalf("LoadPage() = %d bytes, want nil", len(data))
+				}
+				return
+			}
+
+			var response model.WebToolsResponse
+			err = xml.Unmarshal(data, &response)
+			if err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+			if len(response.WebToolsEvents) != len(tt.wantEvents) {
+				t.Fatalf("events count = %d, want %d", len(response.WebToolsEvents), len(tt.wantEvents))
+			}
+			for i, event := range response.WebToolsEvents {
+				if event.EventID != tt.wantEvents[i] {
+					t.Errorf("event %d id = %s, want %s", i, event.EventID, tt.wantEvents[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewDirectoryFeedMissingDir(t *testing.T) {
+	_, err := NewDirectoryFeed(filepath.Join(t.TempDir(), "missing"))
+	if err == nil {
+		t.Fatal("NewDirectoryFeed() error = nil, want error")
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	data, err := os.ReadFile(pageFilePath(fixtureDir, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var response model.WebToolsResponse
+	err = xml.Unmarshal(data, &response)
+	if err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+
+	if response.Deprecated != "This feed is replaced by eventXML18" {
+		t.Errorf("Deprecated = %q", response.Deprecated)
+	}
+	if response.EndOfService != "2027-06-30" {
+		t.Errorf("EndOfService = %q", response.EndOfService)
+	}
+
+	concert := response.WebToolsEvents[0]
+	if concert.Title != "Symphony Orchestra & Choir" {
+		t.Errorf("Title = %q", concert.Title)
+	}
+	if concert.Description != `<p>An evening of <b>Brahms</b>. <a href="https://krannertcenter.com/tickets">Tickets</a></p><script>alert(1)</script>` {
+		t.Errorf("Description = %q", concert.Description)
+	}
+	if concert.Cost != "$10 students, $25 general admission" || concert.CostFree != "false" {
+		t.Errorf("Cost = %q, CostFree = %q", concert.Cost, concert.CostFree)
+	}
+	if len(concert.Topic) != 2 || concert.Topic[0].Name != "Arts" || concert.Topic[1].ID != "15" {
+		t.Errorf("Topic = %+v", concert.Topic)
+	}
+
+	workshop := response.WebToolsEvents[1]
+	if workshop.VirtualEvent != "true" || workshop.VirtualEventURL != "https://illinois.zoom.us/j/123456789" {
+		t.Errorf("VirtualEvent = %q, VirtualEventURL = %q", workshop.VirtualEvent, workshop.VirtualEventURL)
+	}
+	if workshop.Recurrence != "true" || workshop.RecurrenceID != "2" {
+		t.Errorf("Recurrence = %q, RecurrenceID = %q", workshop.Recurrence, workshop.RecurrenceID)
+	}
+}
+
+func TestRecorderReplay(t *testing.T) {
+	//the server gives the saved pages and an empty page after them like the web tools server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := os.ReadFile(pageFilePath(fixtureDir, page))
+		if err != nil {
+			data = []byte("<responseWS></responseWS>")
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(NewHTTPFeed(server.URL), dir)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	recorded := map[int][]byte{}
+	for page := 0; page < 3; page++ {
+		recorded[page], err = recorder.LoadPage(page)
+		if err != nil {
+			t.Fatalf("LoadPage(%d) error = %v", page, err)
+		}
+	}
+
+	replay, err := NewDirectoryFeed(dir)
+	if err != nil {
+		t.Fatalf("NewDirectoryFeed() error = %v", err)
+	}
+	for page, want := range recorded {
+		got, err := replay.LoadPage(page)
+		if err != nil {
+			t.Fatalf("replayed LoadPage(%d) error = %v", page, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("replayed page %d differs from the recorded one", page)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webtools
+
+import (
+	"fmt"
+	"os"
+)
+
+// Recorder saves the pages loaded from the web tools server in a local directory, so that they can be replayed with DirectoryFeed
+type Recorder struct {
+	feed HTTPFeed
+	dir  string
+}
+
+// LoadPage loads the feed page and saves it
+func (r Recorder) LoadPage(page int) ([]byte, error) {
+	data, err := r.feed.LoadPage(page)
+	if err != nil || data == nil {
+		return data, err
+	}
+
+	err = os.WriteFile(pageFilePath(r.dir, page), data, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error recording web tools page %d: %w", page, err)
+	}
+	return data, nil
+}
+
+// NewRecorder creates new recorder of the feed pages
+func NewRecorder(feed HTTPFeed, dir string) (*Recorder, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating web tools recording directory: %w", err)
+	}
+	return &Recorder{feed: feed, dir: dir}, nil
+}
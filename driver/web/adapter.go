@@ -85,6 +85,14 @@ func (a Adapter) Start() {
 	mainRouter.HandleFunc("/wayfinding/building", a.wrapFunc(a.clientAPIsHandler.getBuilding, a.auth.client.Standard)).Methods("GET")
 	mainRouter.HandleFunc("/wayfinding/entrance", a.wrapFunc(a.clientAPIsHandler.getEntrance, a.auth.client.Standard)).Methods("GET")
 	mainRouter.HandleFunc("/wayfinding/buildings", a.wrapFunc(a.clientAPIsHandler.getBuildings, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/buildings/version", a.wrapFunc(a.clientAPIsHandler.getBuildingsVersion, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/buildings/search", a.wrapFunc(a.clientAPIsHandler.searchBuildingsRanked, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/buildings/features/search", a.wrapFunc(a.clientAPIsHandler.searchBuildingFeatures, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/nearby", a.wrapFunc(a.clientAPIsHandler.getBuildingsNear, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/directions", a.wrapFunc(a.clientAPIsHandler.getWalkingDirections, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/geojson/buildings", a.wrapFunc(a.clientAPIsHandler.getBuildingsGeoJSON, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/geojson/entrances", a.wrapFunc(a.clientAPIsHandler.getEntrancesGeoJSON, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/wayfinding/geojson/features", a.wrapFunc(a.clientAPIsHandler.getFeaturesGeoJSON, a.auth.client.Standard)).Methods("GET")
 	mainRouter.HandleFunc("/wayfinding/floorplan", a.wrapFunc(a.clientAPIsHandler.getFloorPlan, a.auth.client.Standard)).Methods("GET")
 	mainRouter.HandleFunc("/wayfinding/searchbuildings", a.wrapFunc(a.clientAPIsHandler.searchBuildings, a.auth.client.Standard)).Methods("GET")
 
@@ -101,6 +109,16 @@ func (a Adapter) Start() {
 	mainRouter.HandleFunc("/crowdmeter/location", a.wrapFunc(a.clientAPIsHandler.getCrowdMeterDataByLocation, a.auth.client.Standard)).Methods("GET")
 	mainRouter.HandleFunc("/crowdmeter/type", a.wrapFunc(a.clientAPIsHandler.getCrowdMeterDataByType, a.auth.client.Standard)).Methods("GET")
 
+	mainRouter.HandleFunc("/events/search", a.wrapFunc(a.clientAPIsHandler.searchLegacyEvents, a.auth.client.Standard)).Methods("GET")
+	mainRouter.HandleFunc("/events/nearby", a.wrapFunc(a.clientAPIsHandler.getLegacyEventsNear, a.auth.client.Standard)).Methods("GET")
+
+	//the events feeds are read by websites and signage systems so they do not require a token
+	mainRouter.HandleFunc("/events/rss", a.wrapFunc(a.clientAPIsHandler.getLegacyEventsRSS, nil)).Methods("GET")
+	mainRouter.HandleFunc("/events/atom", a.wrapFunc(a.clientAPIsHandler.getLegacyEventsAtom, nil)).Methods("GET")
+
+	//the event images are loaded directly by the apps so they do not require a token
+	mainRouter.HandleFunc("/images/{key:.+}", a.wrapFunc(a.clientAPIsHandler.getImage, nil)).Methods("GET")
+
 	// Admin APIs
 	adminRouter := mainRouter.PathPrefix("/admin").Subrouter()
 	adminRouter.HandleFunc("/examples/{id}", a.wrapFunc(a.adminAPIsHandler.getExample, a.auth.admin.Permissions)).Methods("GET")
@@ -124,6 +142,25 @@ func (a Adapter) Start() {
 	adminRouter.HandleFunc("/events/webtools-blacklist", a.wrapFunc(a.adminAPIsHandler.removewebtoolsblacklist, a.auth.admin.Permissions)).Methods("DELETE")
 	adminRouter.HandleFunc("/events/summary", a.wrapFunc(a.adminAPIsHandler.getEventsSummary, a.auth.admin.Permissions)).Methods("GET")
 	adminRouter.HandleFunc("/events/load", a.wrapFunc(a.adminAPIsHandler.loadEvents, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/events/archive", a.wrapFunc(a.adminAPIsHandler.searchArchivedEvents, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/events/webtools-sync-reports", a.wrapFunc(a.adminAPIsHandler.getWebToolsSyncReports, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/events/events-bb-reconciliation", a.wrapFunc(a.adminAPIsHandler.reconcileEventsBB, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/events/moderation", a.wrapFunc(a.adminAPIsHandler.getEventsModerationQueue, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/events/moderation/{id}/approve", a.wrapFunc(a.adminAPIsHandler.approveEvent, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/events/moderation/{id}/reject", a.wrapFunc(a.adminAPIsHandler.rejectEvent, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/events/{id}/history", a.wrapFunc(a.adminAPIsHandler.getEventHistory, a.auth.admin.Permissions)).Methods("GET")
+
+	adminRouter.HandleFunc("/buildings/aliases", a.wrapFunc(a.adminAPIsHandler.getBuildingAliases, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/buildings/aliases", a.wrapFunc(a.adminAPIsHandler.createBuildingAlias, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/buildings/aliases/{id}", a.wrapFunc(a.adminAPIsHandler.deleteBuildingAlias, a.auth.admin.Permissions)).Methods("DELETE")
+	adminRouter.HandleFunc("/buildings/features/catalog", a.wrapFunc(a.adminAPIsHandler.getBuildingFeatureCatalog, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/buildings/features/catalog", a.wrapFunc(a.adminAPIsHandler.createBuildingFeatureCatalogEntry, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/buildings/features/catalog/{code}", a.wrapFunc(a.adminAPIsHandler.updateBuildingFeatureCatalogEntry, a.auth.admin.Permissions)).Methods("PUT")
+	adminRouter.HandleFunc("/buildings/features/catalog/{code}", a.wrapFunc(a.adminAPIsHandler.deleteBuildingFeatureCatalogEntry, a.auth.admin.Permissions)).Methods("DELETE")
+	adminRouter.HandleFunc("/buildings/features/locations", a.wrapFunc(a.adminAPIsHandler.getFeatureLocations, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/buildings/features/locations", a.wrapFunc(a.adminAPIsHandler.createFeatureLocation, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/buildings/features/locations/{id}", a.wrapFunc(a.adminAPIsHandler.updateFeatureLocation, a.auth.admin.Permissions)).Methods("PUT")
+	adminRouter.HandleFunc("/buildings/features/locations/{id}", a.wrapFunc(a.adminAPIsHandler.deleteFeatureLocation, a.auth.admin.Permissions)).Methods("DELETE")
 
 	// BB APIs
 	bbsRouter := mainRouter.PathPrefix("/bbs").Subrouter()
@@ -139,12 +176,16 @@ func (a Adapter) Start() {
 
 	//use api key!!!
 	bbsRouter.HandleFunc("/events", a.wrapFunc(a.apiKeyHandler.getLegacyEvents, a.auth.apiKey)).Methods("GET")
+	bbsRouter.HandleFunc("/events/search", a.wrapFunc(a.apiKeyHandler.searchLegacyEvents, a.auth.apiKey)).Methods("GET")
 
 	// TPS APIs
 	tpsRouter := mainRouter.PathPrefix("/tps").Subrouter()
 	tpsRouter.HandleFunc("/examples/{id}", a.wrapFunc(a.tpsAPIsHandler.getExample, a.auth.tps.Permissions)).Methods("GET")
 	tpsRouter.HandleFunc("/events", a.wrapFunc(a.tpsAPIsHandler.createEvents, a.auth.tps.Permissions)).Methods("POST")
 	tpsRouter.HandleFunc("/events", a.wrapFunc(a.tpsAPIsHandler.deleteEvents, a.auth.tps.Permissions)).Methods("DELETE")
+	tpsRouter.HandleFunc("/events", a.wrapFunc(a.tpsAPIsHandler.getEvents, a.auth.tps.Permissions)).Methods("GET")
+	tpsRouter.HandleFunc("/events/moderation", a.wrapFunc(a.tpsAPIsHandler.getEventsModeration, a.auth.tps.Permissions)).Methods("GET")
+	tpsRouter.HandleFunc("/events/{id}", a.wrapFunc(a.tpsAPIsHandler.getEvent, a.auth.tps.Permissions)).Methods("GET")
 
 	// System APIs
 	systemRouter := mainRouter.PathPrefix("/system").Subrouter()
@@ -20,10 +20,12 @@ import (
 	Def "application/driver/web/docs/gen"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/tokenauth"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/rokwireutils"
@@ -271,7 +273,7 @@ func (h AdminAPIsHandler) addwebtoolsblacklist(l *logs.Log, r *http.Request, cla
 		}
 	}
 
-	err = h.app.Admin.AddWebtoolsBlackList(dataSourceIDs, dataCalendarIDs, dataOriginatingCalendarIDs)
+	err = h.app.Admin.AddWebtoolsBlackList(claims.OrgID, claims.AppID, dataSourceIDs, dataCalendarIDs, dataOriginatingCalendarIDs)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
 	}
@@ -281,7 +283,7 @@ func (h AdminAPIsHandler) addwebtoolsblacklist(l *logs.Log, r *http.Request, cla
 
 func (h AdminAPIsHandler) getwebtoolsblacklist(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
 
-	blacklist, err := h.app.Admin.GetWebtoolsBlackList()
+	blacklist, err := h.app.Admin.GetWebtoolsBlackList(claims.OrgID, claims.AppID)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
 	}
@@ -322,7 +324,7 @@ func (h AdminAPIsHandler) removewebtoolsblacklist(l *logs.Log, r *http.Request,
 		originatingCalendarIdsList = nil
 	}
 
-	err := h.app.Admin.RemoveWebtoolsBlackList(sourceIdsList, calendarIdsList, originatingCalendarIdsList)
+	err := h.app.Admin.RemoveWebtoolsBlackList(claims.OrgID, claims.AppID, sourceIdsList, calendarIdsList, originatingCalendarIdsList)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
 	}
@@ -331,7 +333,7 @@ func (h AdminAPIsHandler) removewebtoolsblacklist(l *logs.Log, r *http.Request,
 }
 
 func (h AdminAPIsHandler) getEventsSummary(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	summary, err := h.app.Admin.GetEventsSummary()
+	summary, err := h.app.Admin.GetEventsSummary(claims.OrgID, claims.AppID)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
 	}
@@ -375,7 +377,7 @@ func (h AdminAPIsHandler) loadEvents(l *logs.Log, r *http.Request, claims *token
 		originatingCalendarID = &originatingCalendarIDParam
 	}
 
-	events, err := h.app.Admin.GetEventsItems(source, status, dataSourceEventID, calendarID, originatingCalendarID)
+	events, err := h.app.Admin.GetEventsItems(claims.OrgID, claims.AppID, source, status, dataSourceEventID, calendarID, originatingCalendarID)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
 	}
@@ -390,7 +392,394 @@ func (h AdminAPIsHandler) loadEvents(l *logs.Log, r *http.Request, claims *token
 	return l.HTTPResponseSuccessJSON(data)
 }
 
+func (h AdminAPIsHandler) searchArchivedEvents(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+
+	if source := r.URL.Query().Get("source"); len(source) > 0 {
+		query.Source = &source
+	}
+	if status := r.URL.Query().Get("status"); len(status) > 0 {
+		query.Status = &status
+	}
+	query.OrgID = claims.OrgID
+	query.AppID = claims.AppID
+
+	events, err := h.app.Admin.SearchArchivedEvents(*query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(archivedLegacyEventsItemsToDef(events))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) getEventHistory(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	history, err := h.app.Admin.GetEventHistory(claims.OrgID, claims.AppID, id)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEventHistory, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(legacyEventHistoryItemsToDef(history))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) getWebToolsSyncReports(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	limit := int64(20)
+	if limitArg := r.URL.Query().Get("limit"); len(limitArg) > 0 {
+		value, err := strconv.ParseInt(limitArg, 10, 64)
+		if err != nil || value <= 0 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("limit"), err, http.StatusBadRequest, false)
+		}
+		limit = value
+	}
+
+	reports, err := h.app.Admin.GetWebToolsSyncReports(claims.OrgID, claims.AppID, limit)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeWebToolsSyncReport, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(webToolsSyncReportsToDef(reports))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) reconcileEventsBB(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	backfillGateway := false
+	backfillEventsBB := false
+	for _, direction := range listParam(r.URL.Query().Get("backfill")) {
+		switch direction {
+		case "gateway":
+			backfillGateway = true
+		case "events_bb":
+			backfillEventsBB = true
+		default:
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("backfill"), nil, http.StatusBadRequest, false)
+		}
+	}
+
+	reconciliation, err := h.app.Admin.ReconcileEventsBB(claims.OrgID, claims.AppID, backfillGateway, backfillEventsBB)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeLegacyEventsReconciliation, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(legacyEventsReconciliationToDef(*reconciliation))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) getEventsModerationQueue(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	items, err := h.app.Admin.GetEventsModerationQueue(claims.OrgID, claims.AppID)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(legacyEventsItemsToDef(items))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) approveEvent(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	return h.moderateEvent(l, r, claims, true, "")
+}
+
+func (h AdminAPIsHandler) rejectEvent(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	var requestData Def.AdminReqRejectEvent
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+
+	reason := strings.TrimSpace(requestData.Reason)
+	if len(reason) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("reason"), nil, http.StatusBadRequest, false)
+	}
+
+	return h.moderateEvent(l, r, claims, false, reason)
+}
+
+func (h AdminAPIsHandler) moderateEvent(l *logs.Log, r *http.Request, claims *tokenauth.Claims, approved bool, reason string) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	item, err := h.app.Admin.ModerateEvent(claims.OrgID, claims.AppID, id, approved, reason, claims.Subject)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUpdate, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+	if item == nil {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, model.TypeLegacyEvents, &logutils.FieldArgs{"id": id}, nil, http.StatusNotFound, false)
+	}
+
+	data, err := json.Marshal(legacyEventItemToDef(*item))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
 // NewAdminAPIsHandler creates new rest Handler instance
 func NewAdminAPIsHandler(app *core.Application) AdminAPIsHandler {
 	return AdminAPIsHandler{app: app}
 }
+
+func (h AdminAPIsHandler) getBuildingAliases(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	aliases, err := h.app.Admin.GetBuildingAliases()
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeBuildingAlias, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(buildingAliasesToDef(aliases))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) createBuildingAlias(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	var requestData Def.AdminReqCreateBuildingAlias
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+	if len(strings.TrimSpace(requestData.Alias)) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("alias"), nil, http.StatusBadRequest, false)
+	}
+	if len(requestData.BuildingId) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("building_id"), nil, http.StatusBadRequest, false)
+	}
+
+	alias, err := h.app.Admin.CreateBuildingAlias(requestData.Alias, requestData.BuildingId)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeBuildingAlias, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	data, err := json.Marshal(buildingAliasToDef(*alias))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) deleteBuildingAlias(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	err := h.app.Admin.DeleteBuildingAlias(id)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeBuildingAlias, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	return l.HTTPResponseSuccess()
+}
+
+func (h AdminAPIsHandler) getBuildingFeatureCatalog(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	catalog, err := h.app.Admin.GetBuildingFeatureCatalog()
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeAppBuildingFeature, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(appBuildingFeaturesToDef(catalog))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) createBuildingFeatureCatalogEntry(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	var requestData Def.PostApiAdminBuildingsFeaturesCatalogJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+	if len(strings.TrimSpace(requestData.CampusCode)) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("campus_code"), nil, http.StatusBadRequest, false)
+	}
+	if len(strings.TrimSpace(requestData.AppCode)) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("app_code"), nil, http.StatusBadRequest, false)
+	}
+
+	item, err := h.app.Admin.CreateBuildingFeatureCatalogEntry(appBuildingFeatureFromDef(requestData))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeAppBuildingFeature, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	data, err := json.Marshal(appBuildingFeatureToDef(*item))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) updateBuildingFeatureCatalogEntry(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	code := params["code"]
+	if len(code) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("code"), nil, http.StatusBadRequest, false)
+	}
+
+	var requestData Def.PutApiAdminBuildingsFeaturesCatalogCodeJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+	if len(strings.TrimSpace(requestData.AppCode)) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("app_code"), nil, http.StatusBadRequest, false)
+	}
+
+	//the campus code identifies the entry, it is given by the path
+	item := appBuildingFeatureFromDef(requestData)
+	item.CampusCode = code
+	err = h.app.Admin.UpdateBuildingFeatureCatalogEntry(item)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUpdate, model.TypeAppBuildingFeature, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	return l.HTTPResponseSuccess()
+}
+
+func (h AdminAPIsHandler) deleteBuildingFeatureCatalogEntry(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	code := params["code"]
+	if len(code) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("code"), nil, http.StatusBadRequest, false)
+	}
+
+	err := h.app.Admin.DeleteBuildingFeatureCatalogEntry(code)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeAppBuildingFeature, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	return l.HTTPResponseSuccess()
+}
+
+func (h AdminAPIsHandler) getFeatureLocations(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	var buildingNumber *string
+	if building := r.URL.Query().Get("building"); len(building) > 0 {
+		buildingNumber = &building
+	}
+
+	locations, err := h.app.Admin.GetFeatureLocations(buildingNumber)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeManagedFeatureLocation, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(managedFeatureLocationsToDef(locations))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) createFeatureLocation(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	var requestData Def.PostApiAdminBuildingsFeaturesLocationsJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+	if len(requestData.BuildingNumber) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("building_number"), nil, http.StatusBadRequest, false)
+	}
+	if len(strings.TrimSpace(requestData.Key)) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeRequestBody, logutils.StringArgs("key"), nil, http.StatusBadRequest, false)
+	}
+
+	location, err := h.app.Admin.CreateFeatureLocation(requestData.BuildingNumber, requestData.Key, requestData.Name, requestData.Floors)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeManagedFeatureLocation, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	data, err := json.Marshal(managedFeatureLocationToDef(*location))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h AdminAPIsHandler) updateFeatureLocation(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	var requestData Def.PutApiAdminBuildingsFeaturesLocationsIdJSONRequestBody
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+	}
+
+	err = h.app.Admin.UpdateFeatureLocation(id, requestData.Name, requestData.Floors)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUpdate, model.TypeManagedFeatureLocation, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	return l.HTTPResponseSuccess()
+}
+
+func (h AdminAPIsHandler) deleteFeatureLocation(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	err := h.app.Admin.DeleteFeatureLocation(id)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeManagedFeatureLocation, nil, err, dataErrorStatusCode(err), true)
+	}
+
+	return l.HTTPResponseSuccess()
+}
+
+// dataErrorStatusCode gives the response code of the error by the status of its data - 400 when invalid, 404 when missing,
+// 409 when already found and 500 for the other errors
+func dataErrorStatusCode(err error) int {
+	switch errors.Status(err) {
+	case string(logutils.StatusInvalid):
+		return http.StatusBadRequest
+	case string(logutils.StatusMissing):
+		return http.StatusNotFound
+	case string(logutils.StatusFound):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
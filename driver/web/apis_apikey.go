@@ -31,7 +31,13 @@ type APIKeyHandler struct {
 }
 
 func (h APIKeyHandler) getLegacyEvents(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	legacyEvents, err := h.app.BBs.GetLegacyEvents()
+	var query model.LegacyEventsQuery
+	param, err := legacyEventsAttendanceFromRequest(r, &query)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+
+	legacyEvents, err := h.app.BBs.GetLegacyEvents(query.Audiences, query.AttendanceModes)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeAppointments, nil, err, http.StatusInternalServerError, true)
 	}
@@ -42,6 +48,26 @@ func (h APIKeyHandler) getLegacyEvents(l *logs.Log, r *http.Request, claims *tok
 	return l.HTTPResponseSuccessJSON(response)
 }
 
+func (h APIKeyHandler) searchLegacyEvents(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+	if len(query.Text) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("text"), nil, http.StatusBadRequest, false)
+	}
+
+	legacyEvents, err := h.app.BBs.SearchLegacyEvents(*query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+	response, err := json.Marshal(legacyEvents)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(response)
+}
+
 // NewAPIKeyHandler creates new api key handler
 func NewAPIKeyHandler(app *core.Application) APIKeyHandler {
 	return APIKeyHandler{app: app}
@@ -23,6 +23,8 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/tokenauth"
@@ -145,10 +147,10 @@ func (h ClientAPIsHandler) getBuilding(l *logs.Log, r *http.Request, claims *tok
 // @ID Entrance
 // @Param id query string true "Building identifier"
 // @Param adaOnly query bool false "ADA entrances filter"
-// @Param lat query number true "latitude coordinate of the user"
-// @Param long query number true "longitude coordinate of the user"
+// @Param lat query number false "latitude coordinate of the user"
+// @Param long query number false "longitude coordinate of the user"
 // @Accept  json
-// @Success 200 {object} model.Entrance
+// @Success 200 {object} model.NearestEntrance
 // @Failure 404 {object} rest.errorMessage
 // @Security RokwireAuth
 // @Router /wayfinding/entrance [get]
@@ -236,6 +238,309 @@ func (h ClientAPIsHandler) getBuildings(l *logs.Log, r *http.Request, claims *to
 	return l.HTTPResponseSuccessJSON(resAsJSON)
 }
 
+// getBuildingsVersion returns the current version of the campus buildings dataset
+// @Summary Get the version of the campus buildings dataset and its changes from the previous version
+// @Tags Client
+// @ID BuildingsVersion
+// @Accept  json
+// @Produce json
+// @Success 200 {object} Def.BuildingsDatasetVersion
+// @Security RokwireAuth
+// @Router /wayfinding/buildings/version [get]
+func (h ClientAPIsHandler) getBuildingsVersion(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	dataset, err := h.app.Client.GetBuildingsDataset()
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeBuildingsDataset, nil, err, http.StatusInternalServerError, true)
+	}
+
+	resAsJSON, err := json.Marshal(buildingsDatasetVersionToDef(*dataset))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// searchBuildingsRanked returns the buildings matching the text, the most relevant come first
+// @Summary Get the buildings (compact or full) matching the text by their name, short name, number, address or alias with the relevance scores
+// @Tags Client
+// @ID SearchBuildingsRanked
+// @Accept json
+// @Produce json
+// @success 200 {object} []buildingSearchResultResponse
+// @Security RokwireAuth
+// @Router /wayfinding/buildings/search [get]
+// @Param text query string true "searched text"
+// @Param v query string false "Verbosity"
+// @Param limit query int false "maximum number of buildings"
+func (h ClientAPIsHandler) searchBuildingsRanked(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	text := strings.TrimSpace(r.URL.Query().Get("text"))
+	if len(text) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("text"), nil, http.StatusBadRequest, false)
+	}
+	returnCompact := r.URL.Query().Get("v") != "2"
+
+	limit := 0
+	if limitArg := r.URL.Query().Get("limit"); len(limitArg) > 0 {
+		value, err := strconv.Atoi(limitArg)
+		if err != nil || value <= 0 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("limit"), err, http.StatusBadRequest, false)
+		}
+		limit = value
+	}
+
+	results, err := h.app.Client.SearchBuildingsRanked(text, limit)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+
+	resAsJSON, err := json.Marshal(buildingSearchResultsToResponse(results, returnCompact))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// searchBuildingFeatures returns the buildings which have the features matching the text
+// @Summary Get the buildings (compact or full) which have the features matching the text by their key and name
+// @Tags Client
+// @ID SearchBuildingFeatures
+// @Accept json
+// @Produce json
+// @success 200 {object} []buildingFeatureSearchResultResponse
+// @Security RokwireAuth
+// @Router /wayfinding/buildings/features/search [get]
+// @Param text query string true "searched feature"
+// @Param v query string false "Verbosity"
+func (h ClientAPIsHandler) searchBuildingFeatures(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	text := strings.TrimSpace(r.URL.Query().Get("text"))
+	if len(text) == 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("text"), nil, http.StatusBadRequest, false)
+	}
+	returnCompact := r.URL.Query().Get("v") != "2"
+
+	results, err := h.app.Client.SearchBuildingFeatures(text)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+
+	resAsJSON, err := json.Marshal(buildingFeatureSearchResultsToResponse(results, returnCompact))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getBuildingsNear returns the buildings around a point, the closest come first
+// @Summary Get the buildings within the radius of a point by the distance to their nearest available entrance
+// @Tags Client
+// @ID NearbyBuildings
+// @Accept json
+// @Produce json
+// @success 200 {object} []model.NearbyBuilding
+// @Security RokwireAuth
+// @Router /wayfinding/nearby [get]
+// @Param lat query number true "latitude"
+// @Param long query number true "longitude"
+// @Param radius query number false "radius in meters"
+// @Param limit query int false "maximum number of buildings"
+// @Param feature query string false "feature key"
+// @Param adaOnly query bool false "ADA accessible entrances only"
+func (h ClientAPIsHandler) getBuildingsNear(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	latitude, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("lat"), err, http.StatusBadRequest, false)
+	}
+	longitude, err := strconv.ParseFloat(r.URL.Query().Get("long"), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("long"), err, http.StatusBadRequest, false)
+	}
+	radius := 1000.0
+	if radiusArg := r.URL.Query().Get("radius"); len(radiusArg) > 0 {
+		radius, err = strconv.ParseFloat(radiusArg, 64)
+		if err != nil || radius <= 0 || radius > 50000 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("radius"), err, http.StatusBadRequest, false)
+		}
+	}
+	limit := 20
+	if limitArg := r.URL.Query().Get("limit"); len(limitArg) > 0 {
+		limit, err = strconv.Atoi(limitArg)
+		if err != nil || limit <= 0 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("limit"), err, http.StatusBadRequest, false)
+		}
+	}
+	adaOnly := false
+	if adaOnlyArg := r.URL.Query().Get("adaOnly"); len(adaOnlyArg) > 0 {
+		adaOnly, err = strconv.ParseBool(adaOnlyArg)
+		if err != nil {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("adaOnly"), err, http.StatusBadRequest, false)
+		}
+	}
+	feature := r.URL.Query().Get("feature")
+
+	buildings, err := h.app.Client.GetBuildingsNear(latitude, longitude, radius, feature, adaOnly, limit)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(buildings)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getBuildingsGeoJSON returns the buildings map layer
+// @Summary Get the buildings as a GeoJSON feature collection of their footprints, or of points when the footprints are unknown
+// @Tags Client
+// @ID BuildingsGeoJSON
+// @Accept json
+// @Produce json
+// @success 200 {object} geoJSONFeatureCollection
+// @Security RokwireAuth
+// @Router /wayfinding/geojson/buildings [get]
+// @Param bbox query string false "minLong,minLat,maxLong,maxLat bounding box"
+func (h ClientAPIsHandler) getBuildingsGeoJSON(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	bbox, err := model.ParseBoundingBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("bbox"), err, http.StatusBadRequest, false)
+	}
+
+	buildings, err := h.app.Client.GetMapBuildings(bbox)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(mapBuildingsToGeoJSON(buildings))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getEntrancesGeoJSON returns the building entrances map layer
+// @Summary Get the available building entrances as a GeoJSON feature collection of points
+// @Tags Client
+// @ID EntrancesGeoJSON
+// @Accept json
+// @Produce json
+// @success 200 {object} geoJSONFeatureCollection
+// @Security RokwireAuth
+// @Router /wayfinding/geojson/entrances [get]
+// @Param bbox query string false "minLong,minLat,maxLong,maxLat bounding box"
+// @Param adaOnly query bool false "ADA accessible entrances only"
+func (h ClientAPIsHandler) getEntrancesGeoJSON(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	bbox, err := model.ParseBoundingBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("bbox"), err, http.StatusBadRequest, false)
+	}
+	adaOnly := false
+	if adaOnlyArg := r.URL.Query().Get("adaOnly"); len(adaOnlyArg) > 0 {
+		adaOnly, err = strconv.ParseBool(adaOnlyArg)
+		if err != nil {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("adaOnly"), err, http.StatusBadRequest, false)
+		}
+	}
+
+	entrances, err := h.app.Client.GetMapEntrances(bbox, adaOnly)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(mapEntrancesToGeoJSON(entrances))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getFeaturesGeoJSON returns the building features map layer
+// @Summary Get the building features like the elevators or the AEDs as a GeoJSON feature collection of points at the building centers
+// @Tags Client
+// @ID FeaturesGeoJSON
+// @Accept json
+// @Produce json
+// @success 200 {object} geoJSONFeatureCollection
+// @Security RokwireAuth
+// @Router /wayfinding/geojson/features [get]
+// @Param bbox query string false "minLong,minLat,maxLong,maxLat bounding box"
+// @Param feature query string false "feature key"
+func (h ClientAPIsHandler) getFeaturesGeoJSON(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	bbox, err := model.ParseBoundingBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("bbox"), err, http.StatusBadRequest, false)
+	}
+	feature := r.URL.Query().Get("feature")
+
+	features, err := h.app.Client.GetMapFeatures(bbox, feature)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeBuilding, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(mapFeaturesToGeoJSON(features))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getWalkingDirections returns the walking route between two campus points as GeoJSON
+// @Summary Get the walking directions from a point to a building entrance or to another point over the campus paths
+// @Tags Client
+// @ID WalkingDirections
+// @Accept json
+// @Produce json
+// @success 200 {object} geoJSONFeatureCollection
+// @Failure 404 {object} rest.errorMessage
+// @Security RokwireAuth
+// @Router /wayfinding/directions [get]
+// @Param from_lat query number true "origin latitude"
+// @Param from_long query number true "origin longitude"
+// @Param building query string false "destination building id or number"
+// @Param to_lat query number false "destination latitude, required without building"
+// @Param to_long query number false "destination longitude, required without building"
+// @Param adaOnly query bool false "accessible route without stairs to an ADA accessible entrance"
+func (h ClientAPIsHandler) getWalkingDirections(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	fromLatitude, err := strconv.ParseFloat(r.URL.Query().Get("from_lat"), 64)
+	if err != nil || fromLatitude < -90 || fromLatitude > 90 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("from_lat"), err, http.StatusBadRequest, false)
+	}
+	fromLongitude, err := strconv.ParseFloat(r.URL.Query().Get("from_long"), 64)
+	if err != nil || fromLongitude < -180 || fromLongitude > 180 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("from_long"), err, http.StatusBadRequest, false)
+	}
+	building := r.URL.Query().Get("building")
+	var toLatitude, toLongitude float64
+	if len(building) == 0 {
+		toLatitude, err = strconv.ParseFloat(r.URL.Query().Get("to_lat"), 64)
+		if err != nil || toLatitude < -90 || toLatitude > 90 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("to_lat"), err, http.StatusBadRequest, false)
+		}
+		toLongitude, err = strconv.ParseFloat(r.URL.Query().Get("to_long"), 64)
+		if err != nil || toLongitude < -180 || toLongitude > 180 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("to_long"), err, http.StatusBadRequest, false)
+		}
+	}
+	adaOnly := false
+	if adaOnlyArg := r.URL.Query().Get("adaOnly"); len(adaOnlyArg) > 0 {
+		adaOnly, err = strconv.ParseBool(adaOnlyArg)
+		if err != nil {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("adaOnly"), err, http.StatusBadRequest, false)
+		}
+	}
+
+	route, err := h.app.Client.GetWalkingRoute(fromLatitude, fromLongitude, toLatitude, toLongitude, building, adaOnly)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeWalkingRoute, nil, err, http.StatusInternalServerError, true)
+	}
+	if route == nil {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, model.TypeWalkingRoute, nil, nil, http.StatusNotFound, false)
+	}
+	resAsJSON, err := json.Marshal(walkingRouteToGeoJSON(*route))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
 // SearchBuildings returns a list of all buildings where the name contains the search string
 // @Summary Get a list of all buildings (compact or full) that matches the search string
 // @Tags Client
@@ -303,6 +608,7 @@ func (h ClientAPIsHandler) getFloorPlan(l *logs.Log, r *http.Request, claims *to
 	floor := ""
 	markers := "on"
 	highlites := "on"
+	room := ""
 
 	reqParams := utils.ConstructFilter(r)
 
@@ -320,6 +626,10 @@ func (h ClientAPIsHandler) getFloorPlan(l *logs.Log, r *http.Request, claims *to
 		if v.Field == "highlites" {
 			highlites = v.Value[0]
 		}
+
+		if v.Field == "room" {
+			room = v.Value[0]
+		}
 	}
 	if bldgid == "" || bldgid == "nil" {
 		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("bldgid"), nil, http.StatusBadRequest, false)
@@ -328,7 +638,7 @@ func (h ClientAPIsHandler) getFloorPlan(l *logs.Log, r *http.Request, claims *to
 	if floor == "" || floor == "nil" {
 		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("floor"), nil, http.StatusBadRequest, false)
 	}
-	fp, _, err := h.app.Client.GetFloorPlan(bldgid, floor, markers, highlites)
+	fp, _, err := h.app.Client.GetFloorPlan(bldgid, floor, markers, highlites, room)
 
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeFloorPlan, nil, err, http.StatusInternalServerError, true)
@@ -1003,6 +1313,188 @@ func NewClientAPIsHandler(app *core.Application) ClientAPIsHandler {
 	return ClientAPIsHandler{app: app}
 }
 
+// getImage returns an event image kept by the gateway image storage
+// @Summary return an event image
+// @Tags Client
+// @ID GetImage
+// @Produce image/jpeg
+// @success 200 {file} binary
+// @Router /images/{key} [get]
+// @Param key path string true "Image key"
+func (h ClientAPIsHandler) getImage(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	key := params["key"]
+	if len(key) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("key"), nil, http.StatusBadRequest, false)
+	}
+
+	data, contentType, err := h.app.Client.GetImage(key)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeImage, nil, err, dataErrorStatusCode(err), true)
+	}
+	if data == nil {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, model.TypeImage, logutils.StringArgs(key), nil, http.StatusNotFound, false)
+	}
+
+	return l.HTTPResponseSuccessBytes(data, contentType)
+}
+
+// searchLegacyEvents searches the events by text and filters
+// @Summary search the events by text and filters, the most relevant events come first when searching by text, the earliest ones otherwise
+// @Tags Client
+// @ID SearchLegacyEvents
+// @Produce json
+// @success 200 {object} []model.LegacyEvent
+// @Security RokwireAuth
+// @Router /events/search [get]
+// @Param text query string false "Words and quoted phrases to search for, required without other filters"
+// @Param categories query string false "Comma separated categories"
+// @Param calendar_ids query string false "Comma separated calendar ids"
+// @Param originating_calendar_ids query string false "Comma separated originating calendar ids"
+// @Param audiences query string false "Comma separated target audiences"
+// @Param attendance_modes query string false "Comma separated attendance modes - in-person, hybrid or virtual"
+// @Param free_for_students query bool false "Only the events which are free for the students"
+// @Param from query int false "Unix timestamp, the events which have not ended before it"
+// @Param to query int false "Unix timestamp, the events which have started before it"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+func (h ClientAPIsHandler) searchLegacyEvents(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+	//a search without text and filters would give all the events
+	if !query.HasCriteria() {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("text"), nil, http.StatusBadRequest, false)
+	}
+	query.OrgID = claims.OrgID
+	query.AppID = claims.AppID
+
+	legacyEvents, err := h.app.Client.SearchLegacyEvents(*query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(legacyEvents)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
+// getLegacyEventsRSS returns the events as RSS feed
+// @Summary return the upcoming events as RSS 2.0 feed
+// @Tags Client
+// @ID GetLegacyEventsRSS
+// @Produce application/rss+xml
+// @success 200 {file} binary
+// @Router /events/rss [get]
+// @Param categories query string false "Comma separated categories"
+// @Param calendar_ids query string false "Comma separated calendar ids"
+// @Param originating_calendar_ids query string false "Comma separated originating calendar ids"
+// @Param from query int false "Unix timestamp, the events which have not ended before it. Now by default"
+// @Param to query int false "Unix timestamp, the events which have started before it"
+// @Param limit query int false "Limit, at most 200"
+func (h ClientAPIsHandler) getLegacyEventsRSS(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	return h.getLegacyEventsFeed(l, r, legacyEventsToRSS, "application/rss+xml; charset=utf-8")
+}
+
+// getLegacyEventsAtom returns the events as Atom feed
+// @Summary return the upcoming events as Atom feed
+// @Tags Client
+// @ID GetLegacyEventsAtom
+// @Produce application/atom+xml
+// @success 200 {file} binary
+// @Router /events/atom [get]
+// @Param categories query string false "Comma separated categories"
+// @Param calendar_ids query string false "Comma separated calendar ids"
+// @Param originating_calendar_ids query string false "Comma separated originating calendar ids"
+// @Param from query int false "Unix timestamp, the events which have not ended before it. Now by default"
+// @Param to query int false "Unix timestamp, the events which have started before it"
+// @Param limit query int false "Limit, at most 200"
+func (h ClientAPIsHandler) getLegacyEventsAtom(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	return h.getLegacyEventsFeed(l, r, legacyEventsToAtom, "application/atom+xml; charset=utf-8")
+}
+
+// getLegacyEventsFeed renders the valid events which match the request filters.
+// The feeds are read by websites and signage without a token, so they give the events of the web tools feed org and app.
+func (h ClientAPIsHandler) getLegacyEventsFeed(l *logs.Log, r *http.Request,
+	render func(events []model.LegacyEvent, feedURL string, now time.Time) ([]byte, error), contentType string) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+
+	legacyEvents, err := h.app.Client.GetLegacyEventsFeed(*query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := render(legacyEvents, requestURL(r), time.Now())
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessBytes(data, contentType)
+}
+
+// getLegacyEventsNear returns the events near a point
+// @Summary return the upcoming events within radius meters of a point, the closest come first
+// @Tags Client
+// @ID GetLegacyEventsNear
+// @Produce json
+// @success 200 {object} []model.NearbyLegacyEvent
+// @Security RokwireAuth
+// @Router /events/nearby [get]
+// @Param lat query number true "Latitude of the point"
+// @Param long query number true "Longitude of the point"
+// @Param radius query number false "Radius in meters, 1000 by default"
+// @Param categories query string false "Comma separated categories"
+// @Param calendar_ids query string false "Comma separated calendar ids"
+// @Param originating_calendar_ids query string false "Comma separated originating calendar ids"
+// @Param audiences query string false "Comma separated target audiences"
+// @Param attendance_modes query string false "Comma separated attendance modes - in-person, hybrid or virtual"
+// @Param from query int false "Unix timestamp, the events which have not ended before it. Now by default"
+// @Param to query int false "Unix timestamp, the events which have started before it"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+func (h ClientAPIsHandler) getLegacyEventsNear(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+	if query.From == nil {
+		now := time.Now().UTC()
+		query.From = &now
+	}
+	query.OrgID = claims.OrgID
+	query.AppID = claims.AppID
+
+	latitude, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("lat"), err, http.StatusBadRequest, false)
+	}
+	longitude, err := strconv.ParseFloat(r.URL.Query().Get("long"), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("long"), err, http.StatusBadRequest, false)
+	}
+	radius := 1000.0
+	if radiusArg := r.URL.Query().Get("radius"); len(radiusArg) > 0 {
+		radius, err = strconv.ParseFloat(radiusArg, 64)
+		if err != nil || radius <= 0 || radius > 50000 {
+			return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("radius"), err, http.StatusBadRequest, false)
+		}
+	}
+
+	legacyEvents, err := h.app.Client.GetLegacyEventsNear(latitude, longitude, radius, *query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+	resAsJSON, err := json.Marshal(legacyEvents)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResult, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(resAsJSON)
+}
+
 func (h ClientAPIsHandler) setReturnDataOnHTTPError(l *logs.Log, statuscode int) logs.HTTPResponse {
 	switch statuscode {
 	case 401:
@@ -108,6 +108,7 @@ func (h TPSAPIsHandler) createEvents(l *logs.Log, r *http.Request, claims *token
 		status := model.LegacyEventStatus{Name: "valid", ReasonIgnored: nil}
 
 		createdEvent := model.LegacyEventItem{
+			OrgID: claims.OrgID, AppID: claims.AppID,
 			SyncProcessSource: syncSourse, SyncDate: syncDate,
 			Status:     status,
 			Item:       legacyEvent,
@@ -134,7 +135,7 @@ func (h TPSAPIsHandler) deleteEvents(l *logs.Log, r *http.Request, claims *token
 
 	}
 
-	err := h.app.TPS.DeleteEvents(idsList, claims.Subject)
+	err := h.app.TPS.DeleteEvents(claims.OrgID, claims.AppID, idsList, claims.Subject)
 	if err != nil {
 		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeExample, nil, err, http.StatusInternalServerError, true)
 	}
@@ -142,6 +143,69 @@ func (h TPSAPIsHandler) deleteEvents(l *logs.Log, r *http.Request, claims *token
 	return l.HTTPResponseSuccess()
 }
 
+func (h TPSAPIsHandler) getEvents(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	query, param, err := legacyEventsQueryFromRequest(r)
+	if err != nil {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(param), err, http.StatusBadRequest, false)
+	}
+
+	if status := r.URL.Query().Get("status"); len(status) > 0 {
+		query.Status = &status
+	}
+	query.OrgID = claims.OrgID
+	query.AppID = claims.AppID
+	query.CreatorAccountID = &claims.Subject
+
+	items, err := h.app.TPS.GetEvents(*query)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(legacyEventsItemsToDef(items))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h TPSAPIsHandler) getEvent(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+	}
+
+	item, err := h.app.TPS.GetEvent(claims.OrgID, claims.AppID, id, claims.Subject)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+	if item == nil {
+		return l.HTTPResponseErrorData(logutils.StatusMissing, model.TypeLegacyEvents, &logutils.FieldArgs{"id": id}, nil, http.StatusNotFound, false)
+	}
+
+	data, err := json.Marshal(legacyEventItemToDef(*item))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
+func (h TPSAPIsHandler) getEventsModeration(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	items, err := h.app.TPS.GetEventsModeration(claims.OrgID, claims.AppID, claims.Subject)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionFind, model.TypeLegacyEvents, nil, err, http.StatusInternalServerError, true)
+	}
+
+	data, err := json.Marshal(tpsEventsModerationToDef(items))
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+
+	return l.HTTPResponseSuccessJSON(data)
+}
+
 // NewTPSAPIsHandler creates new third-party service API handler instance
 func NewTPSAPIsHandler(app *core.Application) TPSAPIsHandler {
 	return TPSAPIsHandler{app: app}
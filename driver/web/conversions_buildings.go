@@ -0,0 +1,126 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core/model"
+	Def "application/driver/web/docs/gen"
+)
+
+// BuildingsDataset
+
+func buildingsDatasetVersionToDef(item model.BuildingsDataset) Def.BuildingsDatasetVersion {
+	renamed := make([]Def.BuildingRename, len(item.Changes.Renamed))
+	for i, rename := range item.Changes.Renamed {
+		renamed[i] = Def.BuildingRename{Id: rename.ID, OldName: rename.OldName, NewName: rename.NewName}
+	}
+	changes := Def.BuildingsDatasetChanges{Added: item.Changes.Added, Removed: item.Changes.Removed, Renamed: renamed,
+		EntrancesChanged: item.Changes.EntrancesChanged, Updated: item.Changes.Updated}
+
+	return Def.BuildingsDatasetVersion{Version: item.Version, BuildingsCount: len(item.Buildings), Changes: changes,
+		DateCreated: item.DateCreated, DateChecked: item.DateChecked}
+}
+
+// BuildingAlias
+
+func buildingAliasToDef(item model.BuildingAlias) Def.BuildingAlias {
+	return Def.BuildingAlias{Id: item.ID, Alias: item.Alias, BuildingId: item.BuildingID, DateCreated: item.DateCreated}
+}
+
+func buildingAliasesToDef(items []model.BuildingAlias) []Def.BuildingAlias {
+	result := make([]Def.BuildingAlias, len(items))
+	for i, item := range items {
+		result[i] = buildingAliasToDef(item)
+	}
+	return result
+}
+
+// BuildingSearchResult
+
+type buildingSearchResultResponse struct {
+	Score        float64 `json:"score"`
+	MatchedField string  `json:"matched_field"`
+	Building     any     `json:"building"` //model.CompactBuilding or model.Building
+}
+
+// buildingSearchResultsToResponse gives the found buildings with their scores, the buildings are compact unless full is requested
+func buildingSearchResultsToResponse(items []model.BuildingSearchResult, returnCompact bool) []buildingSearchResultResponse {
+	result := make([]buildingSearchResultResponse, len(items))
+	for i, item := range items {
+		result[i] = buildingSearchResultResponse{Score: item.Score, MatchedField: item.MatchedField, Building: item.Building}
+		if returnCompact {
+			v := item.Building
+			result[i].Building = model.CompactBuilding{ID: v.ID, Name: v.Name, FullAddress: v.FullAddress, Latitude: v.Latitude, Longitude: v.Longitude,
+				ImageURL: v.ImageURL, Number: v.Number, ShortName: v.ShortName, Features: v.Features}
+		}
+	}
+	return result
+}
+
+// BuildingFeatureSearchResult
+
+type buildingFeatureSearchResultResponse struct {
+	Building any                           `json:"building"` //model.CompactBuilding or model.Building
+	Feature  model.BuildingFeatureLocation `json:"feature"`
+}
+
+// buildingFeatureSearchResultsToResponse gives the buildings with the found features, the buildings are compact unless full is requested
+func buildingFeatureSearchResultsToResponse(items []model.BuildingFeatureSearchResult, returnCompact bool) []buildingFeatureSearchResultResponse {
+	result := make([]buildingFeatureSearchResultResponse, len(items))
+	for i, item := range items {
+		result[i] = buildingFeatureSearchResultResponse{Building: item.Building, Feature: item.Feature}
+		if returnCompact {
+			v := item.Building
+			result[i].Building = model.CompactBuilding{ID: v.ID, Name: v.Name, FullAddress: v.FullAddress, Latitude: v.Latitude, Longitude: v.Longitude,
+				ImageURL: v.ImageURL, Number: v.Number, ShortName: v.ShortName, Features: v.Features}
+		}
+	}
+	return result
+}
+
+// AppBuildingFeature
+
+func appBuildingFeatureToDef(item model.AppBuildingFeature) Def.AppBuildingFeature {
+	return Def.AppBuildingFeature{CampusName: item.CampusName, CampusCode: item.CampusCode, AppName: item.AppName, AppCode: item.AppCode,
+		ShowInApp: item.ShowInApp}
+}
+
+func appBuildingFeaturesToDef(items []model.AppBuildingFeature) []Def.AppBuildingFeature {
+	result := make([]Def.AppBuildingFeature, len(items))
+	for i, item := range items {
+		result[i] = appBuildingFeatureToDef(item)
+	}
+	return result
+}
+
+func appBuildingFeatureFromDef(item Def.AppBuildingFeature) model.AppBuildingFeature {
+	return model.AppBuildingFeature{CampusName: item.CampusName, CampusCode: item.CampusCode, AppName: item.AppName, AppCode: item.AppCode,
+		ShowInApp: item.ShowInApp}
+}
+
+// ManagedFeatureLocation
+
+func managedFeatureLocationToDef(item model.ManagedFeatureLocation) Def.ManagedFeatureLocation {
+	return Def.ManagedFeatureLocation{Id: item.ID, BuildingNumber: item.BuildingNumber, Key: item.Key, Name: item.Name, Floors: item.Floors,
+		DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+}
+
+func managedFeatureLocationsToDef(items []model.ManagedFeatureLocation) []Def.ManagedFeatureLocation {
+	result := make([]Def.ManagedFeatureLocation, len(items))
+	for i, item := range items {
+		result[i] = managedFeatureLocationToDef(item)
+	}
+	return result
+}
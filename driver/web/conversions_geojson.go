@@ -0,0 +1,149 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core/model"
+	"math"
+)
+
+// GeoJSON
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id,omitempty"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"` //longitude, latitude positions
+}
+
+func newGeoJSONFeatureCollection(features []geoJSONFeature) geoJSONFeatureCollection {
+	return geoJSONFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+func pointGeoJSONGeometry(latitude float64, longitude float64) geoJSONGeometry {
+	return geoJSONGeometry{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// lineGeoJSONGeometry gives a LineString, or a Point when there is a single position
+func lineGeoJSONGeometry(coordinates [][]float64) geoJSONGeometry {
+	if len(coordinates) == 1 {
+		return geoJSONGeometry{Type: "Point", Coordinates: coordinates[0]}
+	}
+	return geoJSONGeometry{Type: "LineString", Coordinates: coordinates}
+}
+
+// MapBuilding
+
+// mapBuildingsToGeoJSON gives the buildings as their footprints, or as points at their centers when the footprints are unknown
+func mapBuildingsToGeoJSON(items []model.MapBuilding) geoJSONFeatureCollection {
+	features := make([]geoJSONFeature, len(items))
+	for i, item := range items {
+		geometry := pointGeoJSONGeometry(item.Latitude, item.Longitude)
+		if item.Footprint != nil {
+			geometry = geoJSONGeometry{Type: "MultiPolygon", Coordinates: item.Footprint.Polygons}
+			if len(item.Footprint.Polygons) == 1 {
+				geometry = geoJSONGeometry{Type: "Polygon", Coordinates: item.Footprint.Polygons[0]}
+			}
+		}
+		featureKeys := make([]string, len(item.Features))
+		for j, feature := range item.Features {
+			featureKeys[j] = feature.Key
+		}
+
+		properties := map[string]any{"id": item.ID, "number": item.Number, "name": item.Name, "short_name": item.ShortName, "address": item.FullAddress,
+			"image_url": item.ImageURL, "latitude": item.Latitude, "longitude": item.Longitude, "floors": item.Floors, "features": featureKeys,
+			"entrances_count": len(item.Entrances), "footprint": item.Footprint != nil}
+		features[i] = geoJSONFeature{Type: "Feature", ID: buildingFeatureID(item.Building), Geometry: geometry, Properties: properties}
+	}
+	return newGeoJSONFeatureCollection(features)
+}
+
+// buildingFeatureID gives the building id, or its number when it has no id
+func buildingFeatureID(item model.Building) string {
+	if len(item.ID) > 0 {
+		return item.ID
+	}
+	return item.Number
+}
+
+// MapEntrance
+
+func mapEntrancesToGeoJSON(items []model.MapEntrance) geoJSONFeatureCollection {
+	features := make([]geoJSONFeature, len(items))
+	for i, item := range items {
+		properties := map[string]any{"id": item.ID, "name": item.Name, "ada_compliant": item.ADACompliant, "available": item.Available, "image_url": item.ImageURL,
+			"building_id": item.BuildingID, "building_number": item.BuildingNumber, "building_name": item.BuildingName}
+		features[i] = geoJSONFeature{Type: "Feature", ID: item.ID, Geometry: pointGeoJSONGeometry(item.Latitude, item.Longitude), Properties: properties}
+	}
+	return newGeoJSONFeatureCollection(features)
+}
+
+// MapFeature
+
+func mapFeaturesToGeoJSON(items []model.MapFeature) geoJSONFeatureCollection {
+	features := make([]geoJSONFeature, len(items))
+	for i, item := range items {
+		properties := map[string]any{"key": item.Key, "name": item.Name, "campus_code": item.CampusCode, "floors": item.Floors,
+			"building_id": item.BuildingID, "building_number": item.BuildingNumber, "building_name": item.BuildingName}
+		features[i] = geoJSONFeature{Type: "Feature", ID: item.BuildingNumber + "-" + item.Key, Geometry: pointGeoJSONGeometry(item.Latitude, item.Longitude),
+			Properties: properties}
+	}
+	return newGeoJSONFeatureCollection(features)
+}
+
+// WalkingRoute
+
+// walkingRouteToGeoJSON gives the route line followed by a feature per step with its instruction
+func walkingRouteToGeoJSON(item model.WalkingRoute) geoJSONFeatureCollection {
+	routeProperties := map[string]any{"feature_type": "route", "distance": roundMeters(item.Distance), "duration": math.Round(item.Duration),
+		"accessible": item.Accessible, "steps_count": len(item.Steps)}
+	if item.Building != nil {
+		routeProperties["building_id"] = item.Building.ID
+		routeProperties["building_number"] = item.Building.Number
+		routeProperties["building_name"] = item.Building.Name
+	}
+	if item.Entrance != nil {
+		routeProperties["entrance_id"] = item.Entrance.ID
+		routeProperties["entrance_name"] = item.Entrance.Name
+		routeProperties["entrance_ada_compliant"] = item.Entrance.ADACompliant
+	}
+	features := []geoJSONFeature{{Type: "Feature", ID: "route", Geometry: lineGeoJSONGeometry(item.Coordinates), Properties: routeProperties}}
+
+	for i, step := range item.Steps {
+		properties := map[string]any{"feature_type": "step", "index": i, "instruction": step.Instruction, "name": step.Name,
+			"distance": roundMeters(step.Distance), "duration": math.Round(step.Distance / item.Distance * item.Duration),
+			"bearing": math.Round(step.Bearing), "stairs": step.Stairs}
+		if item.Distance == 0 {
+			properties["duration"] = 0
+		}
+		features = append(features, geoJSONFeature{Type: "Feature", Geometry: lineGeoJSONGeometry(step.Coordinates), Properties: properties})
+	}
+	return newGeoJSONFeatureCollection(features)
+}
+
+// roundMeters rounds the distance to decimeters
+func roundMeters(distance float64) float64 {
+	return math.Round(distance*10) / 10
+}
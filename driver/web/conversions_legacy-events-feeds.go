@@ -0,0 +1,212 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core/model"
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	//legacyEventsFeedTitle is the title of the RSS and Atom feeds
+	legacyEventsFeedTitle = "Events"
+	//legacyEventsFeedIDPrefix makes the events ids Atom entries ids
+	legacyEventsFeedIDPrefix = "urn:rokwire:events:"
+)
+
+// RSS 2.0
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	Category    string        `xml:"category,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// legacyEventsToRSS renders the events as RSS 2.0 feed, the items are published at the events start dates
+func legacyEventsToRSS(events []model.LegacyEvent, feedURL string, now time.Time) ([]byte, error) {
+	items := make([]rssItem, len(events))
+	for i, event := range events {
+		item := rssItem{Title: event.Title, Link: event.TitleURL, Description: legacyEventFeedSummary(event),
+			Category: event.Category, GUID: rssGUID{IsPermaLink: "false", Value: event.ID}}
+		if startDate := model.ParseLegacyEventDate(event.StartDate); startDate != nil {
+			item.PubDate = startDate.UTC().Format(time.RFC1123Z)
+		}
+		if imageURL := legacyEventFeedImageURL(event); len(imageURL) > 0 {
+			item.Enclosure = &rssEnclosure{URL: imageURL, Length: "0", Type: legacyEventFeedImageType(imageURL)}
+		}
+		items[i] = item
+	}
+
+	feed := rssFeed{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{Title: legacyEventsFeedTitle, Link: feedURL, Description: legacyEventsFeedTitle,
+			LastBuildDate: now.UTC().Format(time.RFC1123Z), SelfLink: atomLink{Href: feedURL, Rel: "self", Type: "application/rss+xml"},
+			Items: items}}
+	return marshalLegacyEventsFeed(feed)
+}
+
+// Atom
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string        `xml:"title"`
+	ID        string        `xml:"id"`
+	Updated   string        `xml:"updated"`
+	Published string        `xml:"published,omitempty"`
+	Links     []atomLink    `xml:"link"`
+	Category  *atomCategory `xml:"category"`
+	Summary   string        `xml:"summary,omitempty"`
+	Content   *atomContent  `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// legacyEventsToAtom renders the events as Atom feed, the entries are published at the events start dates
+func legacyEventsToAtom(events []model.LegacyEvent, feedURL string, now time.Time) ([]byte, error) {
+	updated := now.UTC().Format(time.RFC3339)
+
+	entries := make([]atomEntry, len(events))
+	for i, event := range events {
+		entry := atomEntry{Title: event.Title, ID: legacyEventsFeedIDPrefix + event.ID, Updated: updated,
+			Summary: legacyEventFeedSummary(event), Links: []atomLink{}}
+		if startDate := model.ParseLegacyEventDate(event.StartDate); startDate != nil {
+			entry.Published = startDate.UTC().Format(time.RFC3339)
+		}
+		if modified := model.ParseLegacyEventDate(event.DataModified); modified != nil {
+			entry.Updated = modified.UTC().Format(time.RFC3339)
+		}
+		if len(event.TitleURL) > 0 {
+			entry.Links = append(entry.Links, atomLink{Href: event.TitleURL, Rel: "alternate"})
+		}
+		if imageURL := legacyEventFeedImageURL(event); len(imageURL) > 0 {
+			entry.Links = append(entry.Links, atomLink{Href: imageURL, Rel: "enclosure", Type: legacyEventFeedImageType(imageURL)})
+		}
+		if len(event.Category) > 0 {
+			entry.Category = &atomCategory{Term: event.Category}
+		}
+		if len(event.LongDescription) > 0 {
+			entry.Content = &atomContent{Type: "html", Value: event.LongDescription}
+		}
+		entries[i] = entry
+	}
+
+	feed := atomFeed{Title: legacyEventsFeedTitle, ID: feedURL, Updated: updated,
+		Link: atomLink{Href: feedURL, Rel: "self", Type: "application/atom+xml"}, Author: atomAuthor{Name: legacyEventsFeedTitle}, Entries: entries}
+	return marshalLegacyEventsFeed(feed)
+}
+
+// requestURL gives the absolute url of the request, the feeds link to themselves
+func requestURL(r *http.Request) string {
+	scheme := "https"
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); len(forwarded) > 0 {
+		scheme = forwarded
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+func marshalLegacyEventsFeed(feed interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// legacyEventFeedSummary gives the plain text description of the event
+func legacyEventFeedSummary(event model.LegacyEvent) string {
+	if len(event.DescriptionText) > 0 {
+		return event.DescriptionText
+	}
+	return strings.TrimSpace(event.LongDescription)
+}
+
+func legacyEventFeedImageURL(event model.LegacyEvent) string {
+	if event.ImageURL == nil {
+		return ""
+	}
+	return strings.TrimSpace(*event.ImageURL)
+}
+
+// legacyEventFeedImageType gives the media type of the image by the url extension, jpeg by default
+func legacyEventFeedImageType(imageURL string) string {
+	extension := path.Ext(strings.SplitN(imageURL, "?", 2)[0])
+	if mediaType := mime.TypeByExtension(extension); strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+	return "image/jpeg"
+}
@@ -17,6 +17,13 @@ package web
 import (
 	"application/core/model"
 	Def "application/driver/web/docs/gen"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // LegacyEventItem
@@ -26,7 +33,8 @@ func legacyEventItemToDef(item model.LegacyEventItem) Def.LegacyEventItem {
 	status := legacyEventStatusToDef(item.Status)
 	legacyEvent := legacyEventToDef(item.Item)
 	return Def.LegacyEventItem{Source: item.SyncProcessSource,
-		Status: status, LegacyEvent: legacyEvent}
+		Status: status, LegacyEvent: legacyEvent, Moderation: legacyEventModerationToDef(item.Moderation),
+		CategoryMapping: legacyEventCategoryMappingToDef(item.CategoryMapping)}
 }
 
 func legacyEventsItemsToDef(items []model.LegacyEventItem) []Def.LegacyEventItem {
@@ -37,23 +45,72 @@ func legacyEventsItemsToDef(items []model.LegacyEventItem) []Def.LegacyEventItem
 	return result
 }
 
+// ArchivedLegacyEventItem
+
+func archivedLegacyEventItemToDef(item model.ArchivedLegacyEventItem) Def.ArchivedLegacyEventItem {
+	legacyEventItem := legacyEventItemToDef(item.LegacyEventItem)
+	return Def.ArchivedLegacyEventItem{Source: legacyEventItem.Source, Status: legacyEventItem.Status,
+		LegacyEvent: legacyEventItem.LegacyEvent, ArchivedAt: item.ArchivedAt, PurgeAt: item.PurgeAt}
+}
+
+func archivedLegacyEventsItemsToDef(items []model.ArchivedLegacyEventItem) []Def.ArchivedLegacyEventItem {
+	result := make([]Def.ArchivedLegacyEventItem, len(items))
+	for i, item := range items {
+		result[i] = archivedLegacyEventItemToDef(item)
+	}
+	return result
+}
+
+// LegacyEventHistoryItem
+
+func legacyEventHistoryItemToDef(item model.LegacyEventHistoryItem) Def.LegacyEventHistoryItem {
+	changes := make([]Def.LegacyEventFieldChange, len(item.Changes))
+	for i, change := range item.Changes {
+		changes[i] = Def.LegacyEventFieldChange{Field: change.Field, Old: change.Old, New: change.New}
+	}
+	return Def.LegacyEventHistoryItem{Id: item.ID, EventId: item.EventID, Version: item.Version,
+		Operation: Def.LegacyEventHistoryItemOperation(item.Operation), Source: item.Source, Actor: item.Actor,
+		Changes: changes, DateCreated: item.DateCreated}
+}
+
+func legacyEventHistoryItemsToDef(items []model.LegacyEventHistoryItem) []Def.LegacyEventHistoryItem {
+	result := make([]Def.LegacyEventHistoryItem, len(items))
+	for i, item := range items {
+		result[i] = legacyEventHistoryItemToDef(item)
+	}
+	return result
+}
+
 // LegacyEvent
 
 func legacyEventToDef(item model.LegacyEvent) Def.LegacyEvent {
+	var attendanceMode *Def.LegacyEventAttendanceMode
+	if len(item.AttendanceMode) > 0 {
+		mode := Def.LegacyEventAttendanceMode(item.AttendanceMode)
+		attendanceMode = &mode
+	}
+
 	return Def.LegacyEvent{
 		AllDay:                  item.AllDay,
+		Athletics:               legacyEventAthleticsToDef(item.Athletics),
+		AttendanceMode:          attendanceMode,
 		CalendarId:              item.CalendarID,
 		Category:                item.Category,
 		Cost:                    item.Cost,
+		CostDetails:             legacyEventCostToDef(item.CostDetails),
 		CreatedBy:               item.CreatedBy,
 		DataModified:            item.DataModified,
 		DataSourceEventId:       item.DataSourceEventID,
 		DateCreated:             item.DateCreated,
+		DescriptionLinks:        descriptionLinksToDef(item.DescriptionLinks),
+		DescriptionMarkdown:     &item.DescriptionMarkdown,
+		DescriptionText:         &item.DescriptionText,
 		EndDate:                 item.EndDate,
 		EventId:                 item.EventID,
 		IcalUrl:                 item.IcalURL,
 		Id:                      item.ID,
 		ImageUrl:                item.ImageURL,
+		Images:                  imagesToDef(item.Images),
 		IsEventFree:             item.IsEventFree,
 		IsSuperEvent:            item.IsSuperEvent,
 		IsVirtual:               item.IsVirtial,
@@ -65,6 +122,7 @@ func legacyEventToDef(item model.LegacyEvent) Def.LegacyEvent {
 		RecurringFlag:           item.RecurringFlag,
 		RegistrationUrl:         item.RegistrationURL,
 		SourceId:                item.SourceID,
+		Speaker:                 &item.Speaker,
 		Sponsor:                 item.Sponsor,
 		StartDate:               item.StartDate,
 		Subcategory:             item.Subcategory,
@@ -72,7 +130,49 @@ func legacyEventToDef(item model.LegacyEvent) Def.LegacyEvent {
 		TargetAudience:          item.TargetAudience,
 		Title:                   item.Title,
 		TitleUrl:                item.TitleURL,
+		VirtualEventUrl:         &item.VirtualEventURL,
+	}
+}
+
+func legacyEventCostToDef(item *model.LegacyEventCost) *Def.LegacyEventCost {
+	if item == nil {
+		return nil
+	}
+	tiers := make([]Def.LegacyEventCostTier, len(item.Tiers))
+	for i, tier := range item.Tiers {
+		tiers[i] = Def.LegacyEventCostTier{Amount: float32(tier.Amount), Currency: tier.Currency, Audience: tier.Audience}
 	}
+	return &Def.LegacyEventCost{Tiers: tiers, Varies: item.Varies}
+}
+
+func legacyEventAthleticsToDef(item *model.LegacyEventAthletics) *Def.LegacyEventAthletics {
+	if item == nil {
+		return nil
+	}
+	return &Def.LegacyEventAthletics{Sport: item.Sport, Opponent: item.Opponent, HomeAway: Def.LegacyEventAthleticsHomeAway(item.HomeAway),
+		Venue: item.Venue, TicketUrl: item.TicketURL}
+}
+
+func imagesToDef(images map[string]string) *map[string]string {
+	if len(images) == 0 {
+		return nil
+	}
+	return &images
+}
+
+func descriptionLinksToDef(items []model.DescriptionLink) *[]struct {
+	Text string `json:"text"`
+	Url  string `json:"url"`
+} {
+	result := make([]struct {
+		Text string `json:"text"`
+		Url  string `json:"url"`
+	}, len(items))
+	for i, item := range items {
+		result[i].Text = item.Text
+		result[i].Url = item.URL
+	}
+	return &result
 }
 
 // LegacyEventStatus
@@ -81,6 +181,33 @@ func legacyEventStatusToDef(item model.LegacyEventStatus) Def.LegacyEventStatus
 	return Def.LegacyEventStatus{Name: item.Name, ReasonIgnored: item.ReasonIgnored}
 }
 
+// LegacyEventCategoryMapping
+
+func legacyEventCategoryMappingToDef(item *model.LegacyEventCategoryMapping) *Def.LegacyEventCategoryMapping {
+	if item == nil {
+		return nil
+	}
+	return &Def.LegacyEventCategoryMapping{Original: item.Original, Mapped: item.Mapped}
+}
+
+// LegacyEventModeration
+
+func legacyEventModerationToDef(item *model.LegacyEventModeration) *Def.LegacyEventModeration {
+	if item == nil {
+		return nil
+	}
+	return &Def.LegacyEventModeration{Status: Def.LegacyEventModerationStatus(item.Status), Reason: item.Reason,
+		ModeratorId: item.ModeratorID, DateModerated: item.DateModerated}
+}
+
+func tpsEventsModerationToDef(items []model.TPSEventModeration) []Def.TPSEventModeration {
+	result := make([]Def.TPSEventModeration, len(items))
+	for i, item := range items {
+		result[i] = Def.TPSEventModeration{Id: item.ID, Title: item.Title, Moderation: *legacyEventModerationToDef(&item.Moderation)}
+	}
+	return result
+}
+
 // ContactsLegacy
 func contactToDef(item Def.TpsReqCreateEventContact) model.ContactLegacy {
 
@@ -109,3 +236,141 @@ func locationsToDef(item []Def.TpsReqCreateEventLocation) []model.LocationLegacy
 	}
 	return result
 }
+
+// LegacyEventsQuery
+
+// legacyEventsQueryFromRequest constructs legacy events query from the request query params.
+// It gives the name of the invalid param on error.
+func legacyEventsQueryFromRequest(r *http.Request) (*model.LegacyEventsQuery, string, error) {
+	params := r.URL.Query()
+	query := model.LegacyEventsQuery{Text: strings.TrimSpace(params.Get("text"))}
+
+	query.Categories = listParam(params.Get("categories"))
+	query.CalendarIDs = listParam(params.Get("calendar_ids"))
+	query.OriginatingCalendarIDs = listParam(params.Get("originating_calendar_ids"))
+
+	param, err := legacyEventsAttendanceFromRequest(r, &query)
+	if err != nil {
+		return nil, param, err
+	}
+
+	if freeForStudents := params.Get("free_for_students"); len(freeForStudents) > 0 {
+		query.FreeForStudents, err = strconv.ParseBool(freeForStudents)
+		if err != nil {
+			return nil, "free_for_students", err
+		}
+	}
+
+	query.From, err = unixTimeParam(params.Get("from"))
+	if err != nil {
+		return nil, "from", err
+	}
+	query.To, err = unixTimeParam(params.Get("to"))
+	if err != nil {
+		return nil, "to", err
+	}
+
+	if limit := params.Get("limit"); len(limit) > 0 {
+		query.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			return nil, "limit", err
+		}
+		if query.Limit < 0 {
+			return nil, "limit", errors.New("negative limit")
+		}
+	}
+	if offset := params.Get("offset"); len(offset) > 0 {
+		query.Offset, err = strconv.ParseInt(offset, 10, 64)
+		if err != nil {
+			return nil, "offset", err
+		}
+		if query.Offset < 0 {
+			return nil, "offset", errors.New("negative offset")
+		}
+	}
+
+	return &query, "", nil
+}
+
+// legacyEventsAttendanceFromRequest sets the audiences and attendance modes of the query from the request query params.
+// It gives the name of the invalid param on error.
+func legacyEventsAttendanceFromRequest(r *http.Request, query *model.LegacyEventsQuery) (string, error) {
+	params := r.URL.Query()
+	query.Audiences = listParam(params.Get("audiences"))
+
+	query.AttendanceModes = listParam(params.Get("attendance_modes"))
+	for _, mode := range query.AttendanceModes {
+		if !slices.Contains(model.LegacyEventAttendanceModes, mode) {
+			return "attendance_modes", fmt.Errorf("unsupported attendance mode %s", mode)
+		}
+	}
+
+	return "", nil
+}
+
+// listParam parses comma separated values, nil for empty value
+func listParam(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// unixTimeParam parses unix timestamp in seconds, nil for empty value
+func unixTimeParam(value string) (*time.Time, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	date := time.Unix(seconds, 0).UTC()
+	return &date, nil
+}
+
+// WebToolsSyncReport
+
+func webToolsSyncReportToDef(item model.WebToolsSyncReport) Def.WebToolsSyncReport {
+	return Def.WebToolsSyncReport{Id: item.ID, Status: Def.WebToolsSyncReportStatus(item.Status), PagesCount: item.PagesCount,
+		FailedPages: item.FailedPages, EventsCount: item.EventsCount, Warnings: item.Warnings, Error: item.Error,
+		CreatedCount: item.CreatedCount, UpdatedCount: item.UpdatedCount, DeletedCount: item.DeletedCount,
+		DateStarted: item.DateStarted, DateFinished: item.DateFinished}
+}
+
+func webToolsSyncReportsToDef(items []model.WebToolsSyncReport) []Def.WebToolsSyncReport {
+	result := make([]Def.WebToolsSyncReport, len(items))
+	for i, item := range items {
+		result[i] = webToolsSyncReportToDef(item)
+	}
+	return result
+}
+
+func legacyEventsReconciliationToDef(item model.LegacyEventsReconciliation) Def.LegacyEventsReconciliation {
+	divergent := make([]Def.LegacyEventDivergence, len(item.Divergent))
+	for i, divergence := range item.Divergent {
+		differences := make([]Def.LegacyEventFieldDifference, len(divergence.Differences))
+		for j, difference := range divergence.Differences {
+			differences[j] = Def.LegacyEventFieldDifference{Field: difference.Field, Gateway: difference.Gateway, EventsBb: difference.EventsBB}
+		}
+		divergent[i] = Def.LegacyEventDivergence{Id: divergence.ID, EventsBbId: divergence.EventsBBID,
+			DataSourceEventId: divergence.DataSourceEventID, Title: divergence.Title, Differences: differences}
+	}
+
+	return Def.LegacyEventsReconciliation{Id: item.ID, EventsBbCount: item.EventsBBCount, GatewayCount: item.GatewayCount,
+		MatchedCount: item.MatchedCount, Missing: legacyEventReconciliationItemsToDef(item.Missing),
+		Existing: legacyEventReconciliationItemsToDef(item.Existing), Extra: legacyEventReconciliationItemsToDef(item.Extra),
+		Divergent: divergent, BackfilledGatewayCount: item.BackfilledGatewayCount, BackfilledEventsBbCount: item.BackfilledEventsBBCount,
+		FailedEventsBb: legacyEventReconciliationItemsToDef(item.FailedEventsBB), DateCreated: item.DateCreated}
+}
+
+func legacyEventReconciliationItemsToDef(items []model.LegacyEventReconciliationItem) []Def.LegacyEventReconciliationItem {
+	result := make([]Def.LegacyEventReconciliationItem, len(items))
+	for i, item := range items {
+		result[i] = Def.LegacyEventReconciliationItem{Id: item.ID, DataSourceEventId: item.DataSourceEventID, Title: item.Title}
+	}
+	return result
+}
@@ -5,6 +5,7 @@ package Def
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -13,6 +14,41 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for LegacyEventAthleticsHomeAway.
+const (
+	Away    LegacyEventAthleticsHomeAway = "away"
+	Home    LegacyEventAthleticsHomeAway = "home"
+	Neutral LegacyEventAthleticsHomeAway = "neutral"
+)
+
+// Defines values for LegacyEventAttendanceMode.
+const (
+	Hybrid   LegacyEventAttendanceMode = "hybrid"
+	InPerson LegacyEventAttendanceMode = "in-person"
+	Virtual  LegacyEventAttendanceMode = "virtual"
+)
+
+// Defines values for LegacyEventHistoryItemOperation.
+const (
+	Created LegacyEventHistoryItemOperation = "created"
+	Deleted LegacyEventHistoryItemOperation = "deleted"
+	Updated LegacyEventHistoryItemOperation = "updated"
+)
+
+// Defines values for LegacyEventModerationStatus.
+const (
+	Approved LegacyEventModerationStatus = "approved"
+	Pending  LegacyEventModerationStatus = "pending"
+	Rejected LegacyEventModerationStatus = "rejected"
+)
+
+// Defines values for WebToolsSyncReportStatus.
+const (
+	Failed     WebToolsSyncReportStatus = "failed"
+	Incomplete WebToolsSyncReportStatus = "incomplete"
+	Succeeded  WebToolsSyncReportStatus = "succeeded"
+)
+
 // AppointmentOptions defines model for AppointmentOptions.
 type AppointmentOptions struct {
 	Questions *[]Question `json:"questions,omitempty"`
@@ -33,12 +69,44 @@ type AppointmentPost struct {
 	UserExternalIds ExternalUserID    `json:"user_external_ids"`
 }
 
+// AppBuildingFeature A feature of the building features catalog
+type AppBuildingFeature struct {
+	// AppCode The key of the feature in the buildings
+	AppCode string `json:"app_code"`
+	AppName string `json:"app_name"`
+
+	// CampusCode The code of the feature in the campus data
+	CampusCode string `json:"campus_code"`
+	CampusName string `json:"campus_name"`
+	ShowInApp  bool   `json:"show_in_app"`
+}
+
+// ArchivedLegacyEventItem defines model for ArchivedLegacyEventItem.
+type ArchivedLegacyEventItem struct {
+	ArchivedAt  time.Time         `json:"archived_at"`
+	LegacyEvent LegacyEvent       `json:"legacy_event"`
+	PurgeAt     time.Time         `json:"purge_at"`
+	Source      string            `json:"source"`
+	Status      LegacyEventStatus `json:"status"`
+}
+
 // BlacklistItems defines model for BlacklistItems.
 type BlacklistItems struct {
 	Data *[]string `json:"data,omitempty"`
 	Name *string   `json:"name,omitempty"`
 }
 
+// BuildingAlias An admin managed nickname of a building used by the buildings search and the event locations
+type BuildingAlias struct {
+	// Alias The alias in lower case without punctuation and words like "the"
+	Alias string `json:"alias"`
+
+	// BuildingId The building id, or its number when it has no id
+	BuildingId  string    `json:"building_id"`
+	DateCreated time.Time `json:"date_created"`
+	Id          string    `json:"id"`
+}
+
 // BuildingBlockAppointment defines model for BuildingBlockAppointment.
 type BuildingBlockAppointment struct {
 	EndTime *string `json:"end_time,omitempty"`
@@ -55,6 +123,52 @@ type BuildingBlockAppointment struct {
 	UserExternalIds ExternalUserID `json:"user_external_ids"`
 }
 
+// BuildingEntrancesConfigData defines model for BuildingEntrancesConfigData.
+type BuildingEntrancesConfigData struct {
+	// PrimaryEntrances The primary entrance id by building number, returned when the user's position is unknown
+	PrimaryEntrances map[string]string `json:"primary_entrances"`
+}
+
+// BuildingRename A building name change
+type BuildingRename struct {
+	Id      string `json:"id"`
+	NewName string `json:"new_name"`
+	OldName string `json:"old_name"`
+}
+
+// BuildingsDatasetChanges The changes of the buildings dataset from the previous version
+type BuildingsDatasetChanges struct {
+	// Added The ids of the added buildings
+	Added []string `json:"added"`
+
+	// EntrancesChanged The ids of the buildings with added, removed or modified entrances
+	EntrancesChanged []string `json:"entrances_changed"`
+
+	// Removed The ids of the removed buildings
+	Removed []string         `json:"removed"`
+	Renamed []BuildingRename `json:"renamed"`
+
+	// Updated The ids of the buildings with any other modified details
+	Updated []string `json:"updated"`
+}
+
+// BuildingsDatasetVersion The current version of the campus buildings dataset
+type BuildingsDatasetVersion struct {
+	BuildingsCount int `json:"buildings_count"`
+
+	// Changes The changes of the buildings dataset from the previous version
+	Changes BuildingsDatasetChanges `json:"changes"`
+
+	// DateChecked The last time the buildings have been confirmed by the building provider
+	DateChecked time.Time `json:"date_checked"`
+
+	// DateCreated When the version has been created
+	DateCreated time.Time `json:"date_created"`
+
+	// Version Increased every time the buildings change
+	Version int `json:"version"`
+}
+
 // Config defines model for Config.
 type Config struct {
 	AppId       *string     `json:"app_id,omitempty"`
@@ -94,22 +208,44 @@ type ExternalUserID struct {
 
 // LegacyEvent defines model for LegacyEvent.
 type LegacyEvent struct {
-	AllDay                  bool      `json:"all_day"`
-	CalendarId              string    `json:"calendar_id"`
-	Category                string    `json:"category"`
-	Cost                    string    `json:"cost"`
-	CreatedBy               string    `json:"created_by"`
-	DataModified            string    `json:"data_modified"`
-	DataSourceEventId       string    `json:"data_source_event_id"`
-	DateCreated             string    `json:"date_created"`
-	EndDate                 string    `json:"end_date"`
-	EventId                 string    `json:"event_id"`
-	IcalUrl                 string    `json:"ical_url"`
-	Id                      string    `json:"id"`
-	ImageUrl                *string   `json:"image_url"`
-	IsEventFree             bool      `json:"is_event_free"`
-	IsSuperEvent            bool      `json:"is_super_event"`
-	IsVirtual               bool      `json:"is_virtual"`
+	AllDay         bool                       `json:"all_day"`
+	Athletics      *LegacyEventAthletics      `json:"athletics,omitempty"`
+	AttendanceMode *LegacyEventAttendanceMode `json:"attendance_mode,omitempty"`
+	CalendarId     string                     `json:"calendar_id"`
+	Category       string                     `json:"category"`
+	Cost           string                     `json:"cost"`
+
+	// CostDetails The structured cost of an event parsed from its cost text
+	CostDetails       *LegacyEventCost `json:"cost_details,omitempty"`
+	CreatedBy         string           `json:"created_by"`
+	DataModified      string           `json:"data_modified"`
+	DataSourceEventId string           `json:"data_source_event_id"`
+	DateCreated       string           `json:"date_created"`
+
+	// DescriptionLinks Links found in the description
+	DescriptionLinks *[]struct {
+		Text string `json:"text"`
+		Url  string `json:"url"`
+	} `json:"description_links,omitempty"`
+
+	// DescriptionMarkdown Markdown of the description
+	DescriptionMarkdown *string `json:"description_markdown,omitempty"`
+
+	// DescriptionText Plain text of the description
+	DescriptionText *string `json:"description_text,omitempty"`
+	EndDate         string  `json:"end_date"`
+	EventId         string  `json:"event_id"`
+	IcalUrl         string  `json:"ical_url"`
+	Id              string  `json:"id"`
+	ImageUrl        *string `json:"image_url"`
+
+	// Images Image renditions URLs by rendition name (thumbnail, card, full)
+	Images       *map[string]string `json:"images"`
+	IsEventFree  bool               `json:"is_event_free"`
+	IsSuperEvent bool               `json:"is_super_event"`
+	IsVirtual    bool               `json:"is_virtual"`
+
+	// LongDescription Sanitized HTML description
 	LongDescription         string    `json:"long_description"`
 	OriginatingCalendarId   string    `json:"originating_calendar_id"`
 	OriginatingCalendarName string    `json:"originating_calendar_name"`
@@ -118,6 +254,7 @@ type LegacyEvent struct {
 	RecurringFlag           bool      `json:"recurring_flag"`
 	RegistrationUrl         string    `json:"registration_url"`
 	SourceId                string    `json:"source_id"`
+	Speaker                 *string   `json:"speaker,omitempty"`
 	Sponsor                 string    `json:"sponsor"`
 	StartDate               string    `json:"start_date"`
 	Subcategory             string    `json:"subcategory"`
@@ -125,13 +262,128 @@ type LegacyEvent struct {
 	TargetAudience          *[]string `json:"target_audience"`
 	Title                   string    `json:"title"`
 	TitleUrl                string    `json:"title_url"`
+	VirtualEventUrl         *string   `json:"virtual_event_url,omitempty"`
+}
+
+// LegacyEventAthletics The athletics details of a game event
+type LegacyEventAthletics struct {
+	HomeAway  LegacyEventAthleticsHomeAway `json:"home_away"`
+	Opponent  string                       `json:"opponent"`
+	Sport     string                       `json:"sport"`
+	TicketUrl string                       `json:"ticket_url"`
+	Venue     string                       `json:"venue"`
+}
+
+// LegacyEventAthleticsHomeAway defines model for LegacyEventAthletics.HomeAway.
+type LegacyEventAthleticsHomeAway string
+
+// LegacyEventAttendanceMode defines model for LegacyEvent.AttendanceMode.
+type LegacyEventAttendanceMode string
+
+// LegacyEventCategoryMapping The conversion of the submitted event category to the gateway one
+type LegacyEventCategoryMapping struct {
+	Mapped   string `json:"mapped"`
+	Original string `json:"original"`
+}
+
+// LegacyEventCost The structured cost of an event parsed from its cost text
+type LegacyEventCost struct {
+	Tiers []LegacyEventCostTier `json:"tiers"`
+
+	// Varies The cost varies or it is a donation
+	Varies bool `json:"varies"`
+}
+
+// LegacyEventCostTier The price of an event for an audience
+type LegacyEventCostTier struct {
+	Amount float32 `json:"amount"`
+
+	// Audience Audience label like `students` or `general public`, empty for everyone
+	Audience string `json:"audience"`
+	Currency string `json:"currency"`
+}
+
+// LegacyEventDivergence defines model for LegacyEventDivergence.
+type LegacyEventDivergence struct {
+	DataSourceEventId string                       `json:"data_source_event_id"`
+	Differences       []LegacyEventFieldDifference `json:"differences"`
+	EventsBbId        string                       `json:"events_bb_id"`
+
+	// Id The gateway event id
+	Id    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// LegacyEventFieldChange defines model for LegacyEventFieldChange.
+type LegacyEventFieldChange struct {
+	// Field The path of the changed field, for example item.location.description
+	Field string `json:"field"`
+
+	// New The value after the change
+	New interface{} `json:"new"`
+
+	// Old The value before the change
+	Old interface{} `json:"old"`
+}
+
+// LegacyEventFieldDifference defines model for LegacyEventFieldDifference.
+type LegacyEventFieldDifference struct {
+	// EventsBb The value in the events BB
+	EventsBb interface{} `json:"events_bb"`
+
+	// Field The path of the field, for example location.description
+	Field string `json:"field"`
+
+	// Gateway The value in the gateway
+	Gateway interface{} `json:"gateway"`
+}
+
+// LegacyEventHistoryItem defines model for LegacyEventHistoryItem.
+type LegacyEventHistoryItem struct {
+	// Actor The account id of the creator or the id of the web tools sync run
+	Actor       string                          `json:"actor"`
+	Changes     []LegacyEventFieldChange        `json:"changes"`
+	DateCreated time.Time                       `json:"date_created"`
+	EventId     string                          `json:"event_id"`
+	Id          string                          `json:"id"`
+	Operation   LegacyEventHistoryItemOperation `json:"operation"`
+
+	// Source webtools-direct / events-tps-api
+	Source  string `json:"source"`
+	Version int    `json:"version"`
 }
 
+// LegacyEventHistoryItemOperation defines model for LegacyEventHistoryItem.Operation.
+type LegacyEventHistoryItemOperation string
+
 // LegacyEventItem defines model for LegacyEventItem.
 type LegacyEventItem struct {
-	LegacyEvent LegacyEvent       `json:"legacy_event"`
-	Source      string            `json:"source"`
-	Status      LegacyEventStatus `json:"status"`
+	// CategoryMapping The conversion of the submitted event category to the gateway one
+	CategoryMapping *LegacyEventCategoryMapping `json:"category_mapping,omitempty"`
+	LegacyEvent     LegacyEvent                 `json:"legacy_event"`
+
+	// Moderation The moderation of an event created by a moderated third-party service account
+	Moderation *LegacyEventModeration `json:"moderation,omitempty"`
+	Source     string                 `json:"source"`
+	Status     LegacyEventStatus      `json:"status"`
+}
+
+// LegacyEventModeration The moderation of an event created by a moderated third-party service account
+type LegacyEventModeration struct {
+	DateModerated *time.Time                  `json:"date_moderated"`
+	ModeratorId   string                      `json:"moderator_id"`
+	Reason        string                      `json:"reason"`
+	Status        LegacyEventModerationStatus `json:"status"`
+}
+
+// LegacyEventModerationStatus defines model for LegacyEventModeration.Status.
+type LegacyEventModerationStatus string
+
+// LegacyEventReconciliationItem defines model for LegacyEventReconciliationItem.
+type LegacyEventReconciliationItem struct {
+	DataSourceEventId string `json:"data_source_event_id"`
+	Id                string `json:"id"`
+	Title             string `json:"title"`
 }
 
 // LegacyEventStatus defines model for LegacyEventStatus.
@@ -140,6 +392,45 @@ type LegacyEventStatus struct {
 	ReasonIgnored *string `json:"reason_ignored"`
 }
 
+// LegacyEventsReconciliation defines model for LegacyEventsReconciliation.
+type LegacyEventsReconciliation struct {
+	BackfilledEventsBbCount int                     `json:"backfilled_events_bb_count"`
+	BackfilledGatewayCount  int                     `json:"backfilled_gateway_count"`
+	DateCreated             time.Time               `json:"date_created"`
+	Divergent               []LegacyEventDivergence `json:"divergent"`
+	EventsBbCount           int                     `json:"events_bb_count"`
+
+	// Existing The events found in the events BB whose ids the gateway uses for events which are not valid, archived or of another org and app
+	Existing []LegacyEventReconciliationItem `json:"existing"`
+
+	// Extra The events found only in the gateway
+	Extra []LegacyEventReconciliationItem `json:"extra"`
+
+	// FailedEventsBb The events which could not be created in the events BB
+	FailedEventsBb []LegacyEventReconciliationItem `json:"failed_events_bb"`
+	GatewayCount   int                             `json:"gateway_count"`
+	Id             string                          `json:"id"`
+	MatchedCount   int                             `json:"matched_count"`
+
+	// Missing The events found only in the events BB
+	Missing []LegacyEventReconciliationItem `json:"missing"`
+}
+
+// ManagedFeatureLocation An admin managed location of a feature in a building
+type ManagedFeatureLocation struct {
+	BuildingNumber string     `json:"building_number"`
+	DateCreated    time.Time  `json:"date_created"`
+	DateUpdated    *time.Time `json:"date_updated"`
+
+	// Floors The floors where the feature is, no floors hide the feature in the building
+	Floors []string `json:"floors"`
+	Id     string   `json:"id"`
+
+	// Key The app code of the feature in the catalog
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
 // OriginatingCalendarItem defines model for OriginatingCalendarItem.
 type OriginatingCalendarItem struct {
 	Count *int    `json:"count,omitempty"`
@@ -174,6 +465,20 @@ type SummaryEvents struct {
 	ValidEventsCount          *int              `json:"valid_events_count,omitempty"`
 }
 
+// TPSEventModeration The moderation of an event as seen by the third-party service account which created it
+type TPSEventModeration struct {
+	Id string `json:"id"`
+
+	// Moderation The moderation of an event created by a moderated third-party service account
+	Moderation LegacyEventModeration `json:"moderation"`
+	Title      string                `json:"title"`
+}
+
+// TPSModerationConfigData defines model for TPSModerationConfigData.
+type TPSModerationConfigData struct {
+	ModeratedAccountIds []string `json:"moderated_account_ids"`
+}
+
 // TPsSource defines model for TPsSource.
 type TPsSource struct {
 	Count *int `json:"count,omitempty"`
@@ -198,6 +503,29 @@ type ValidIgnored struct {
 	WebtoolsSource *interface{} `json:"webtools_source,omitempty"`
 }
 
+// WebToolsSyncReport defines model for WebToolsSyncReport.
+type WebToolsSyncReport struct {
+	CreatedCount int       `json:"created_count"`
+	DateFinished time.Time `json:"date_finished"`
+	DateStarted  time.Time `json:"date_started"`
+	DeletedCount int       `json:"deleted_count"`
+	Error        string    `json:"error"`
+	EventsCount  int       `json:"events_count"`
+	FailedPages  []int     `json:"failed_pages"`
+
+	// Id The sync run id, the actor of the events history records
+	Id           string                   `json:"id"`
+	PagesCount   int                      `json:"pages_count"`
+	Status       WebToolsSyncReportStatus `json:"status"`
+	UpdatedCount int                      `json:"updated_count"`
+
+	// Warnings The feed deprecation and end of service warnings
+	Warnings []string `json:"warnings"`
+}
+
+// WebToolsSyncReportStatus defines model for WebToolsSyncReport.Status.
+type WebToolsSyncReportStatus string
+
 // WebtoolsSource defines model for WebtoolsSource.
 type WebtoolsSource struct {
 	Count               *int                       `json:"count,omitempty"`
@@ -211,6 +539,38 @@ type AdminReqAddWebtoolsBlacklist struct {
 	DataSourceIds              *[]string `json:"data_source_ids"`
 }
 
+// AdminReqCreateBuildingAlias defines model for _admin_req_create-building-alias.
+type AdminReqCreateBuildingAlias struct {
+	Alias string `json:"alias"`
+
+	// BuildingId The building id, or its number when it has no id
+	BuildingId string `json:"building_id"`
+}
+
+// AdminReqCreateFeatureLocation defines model for _admin_req_create-feature-location.
+type AdminReqCreateFeatureLocation struct {
+	BuildingNumber string `json:"building_number"`
+
+	// Floors The floors where the feature is, no floors hide the feature in the building
+	Floors []string `json:"floors"`
+
+	// Key The app code of the feature in the catalog
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// AdminReqRejectEvent defines model for _admin_req_reject-event.
+type AdminReqRejectEvent struct {
+	Reason string `json:"reason"`
+}
+
+// AdminReqUpdateFeatureLocation defines model for _admin_req_update-feature-location.
+type AdminReqUpdateFeatureLocation struct {
+	// Floors The floors where the feature is, no floors hide the feature in the building
+	Floors []string `json:"floors"`
+	Name   string   `json:"name"`
+}
+
 // TpsReqCreateEvent defines model for _tps_req_create-event.
 type TpsReqCreateEvent struct {
 	AllDay            *bool                       `json:"all_day,omitempty"`
@@ -399,12 +759,30 @@ type DeleteApiTpsEventsParams struct {
 // PostApiTpsEventsJSONBody defines parameters for PostApiTpsEvents.
 type PostApiTpsEventsJSONBody = []TpsReqCreateEvent
 
+// PostApiAdminBuildingsAliasesJSONRequestBody defines body for PostApiAdminBuildingsAliases for application/json ContentType.
+type PostApiAdminBuildingsAliasesJSONRequestBody = AdminReqCreateBuildingAlias
+
+// PostApiAdminBuildingsFeaturesCatalogJSONRequestBody defines body for PostApiAdminBuildingsFeaturesCatalog for application/json ContentType.
+type PostApiAdminBuildingsFeaturesCatalogJSONRequestBody = AppBuildingFeature
+
+// PutApiAdminBuildingsFeaturesCatalogCodeJSONRequestBody defines body for PutApiAdminBuildingsFeaturesCatalogCode for application/json ContentType.
+type PutApiAdminBuildingsFeaturesCatalogCodeJSONRequestBody = AppBuildingFeature
+
+// PostApiAdminBuildingsFeaturesLocationsJSONRequestBody defines body for PostApiAdminBuildingsFeaturesLocations for application/json ContentType.
+type PostApiAdminBuildingsFeaturesLocationsJSONRequestBody = AdminReqCreateFeatureLocation
+
+// PutApiAdminBuildingsFeaturesLocationsIdJSONRequestBody defines body for PutApiAdminBuildingsFeaturesLocationsId for application/json ContentType.
+type PutApiAdminBuildingsFeaturesLocationsIdJSONRequestBody = AdminReqUpdateFeatureLocation
+
 // PostApiAdminConfigsJSONRequestBody defines body for PostApiAdminConfigs for application/json ContentType.
 type PostApiAdminConfigsJSONRequestBody = Config
 
 // PutApiAdminConfigsIdJSONRequestBody defines body for PutApiAdminConfigsId for application/json ContentType.
 type PutApiAdminConfigsIdJSONRequestBody = Config
 
+// PostApiAdminEventsModerationIdRejectJSONRequestBody defines body for PostApiAdminEventsModerationIdReject for application/json ContentType.
+type PostApiAdminEventsModerationIdRejectJSONRequestBody = AdminReqRejectEvent
+
 // PutApiAdminEventsWebtoolsBlacklistJSONRequestBody defines body for PutApiAdminEventsWebtoolsBlacklist for application/json ContentType.
 type PutApiAdminEventsWebtoolsBlacklistJSONRequestBody = AdminReqAddWebtoolsBlacklist
 
@@ -452,6 +830,58 @@ func (t *Config_Data) MergeEnvConfigData(v EnvConfigData) error {
 	return err
 }
 
+// AsTPSModerationConfigData returns the union data inside the Config_Data as a TPSModerationConfigData
+func (t Config_Data) AsTPSModerationConfigData() (TPSModerationConfigData, error) {
+	var body TPSModerationConfigData
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTPSModerationConfigData overwrites any union data inside the Config_Data as the provided TPSModerationConfigData
+func (t *Config_Data) FromTPSModerationConfigData(v TPSModerationConfigData) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTPSModerationConfigData performs a merge with any union data inside the Config_Data, using the provided TPSModerationConfigData
+func (t *Config_Data) MergeTPSModerationConfigData(v TPSModerationConfigData) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsBuildingEntrancesConfigData returns the union data inside the Config_Data as a BuildingEntrancesConfigData
+func (t Config_Data) AsBuildingEntrancesConfigData() (BuildingEntrancesConfigData, error) {
+	var body BuildingEntrancesConfigData
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromBuildingEntrancesConfigData overwrites any union data inside the Config_Data as the provided BuildingEntrancesConfigData
+func (t *Config_Data) FromBuildingEntrancesConfigData(v BuildingEntrancesConfigData) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeBuildingEntrancesConfigData performs a merge with any union data inside the Config_Data, using the provided BuildingEntrancesConfigData
+func (t *Config_Data) MergeBuildingEntrancesConfigData(v BuildingEntrancesConfigData) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t Config_Data) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -16,11 +16,16 @@ package main
 
 import (
 	"application/core"
+	"application/core/model"
 	"application/driven/eventsbb"
+	"application/driven/footprints"
 	"application/driven/geo"
 	"application/driven/image"
+	"application/driven/sidearm"
 	"application/driven/storage"
 	"application/driven/uiucadapters"
+	"application/driven/walkingpaths"
+	"application/driven/webtools"
 	"application/driver/web"
 
 	"strings"
@@ -125,19 +130,113 @@ func main() {
 	}
 
 	// image adapter
-	imageBaseURL := envLoader.GetAndLogEnvVar(envPrefix+"CONTENT_BB_BASE_URL", true, true)
-	imageAdapter := image.NewImageAdapter(imageBaseURL, serviceAccountManager, *logger)
+	imageRenditions, err := model.ParseImageRenditions(envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_RENDITIONS", false, false))
 	if err != nil {
-		logger.Fatalf("Error initializing sports adapter: %v", err)
+		logger.Fatalf("Error parsing image renditions: %v", err)
+	}
+	imageRenditionsBackfill := envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_RENDITIONS_BACKFILL", false, false) == "true"
+	var imageAdapter *image.Adapter
+	imagesPublicURL := baseURL + "/api/images"
+	imageStorage := envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_STORAGE", false, false) //content-bb (default), local or s3
+	switch imageStorage {
+	case "local":
+		imageDir := envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_LOCAL_DIR", false, false)
+		if len(imageDir) == 0 {
+			imageDir = "./images"
+		}
+		imageAdapter = image.NewLocalImageAdapter(imageDir, imagesPublicURL, imageRenditions, *logger)
+	case "s3":
+		s3Config := image.S3Config{
+			Endpoint:  envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_S3_ENDPOINT", true, false),
+			Region:    envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_S3_REGION", false, false),
+			Bucket:    envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_S3_BUCKET", true, false),
+			AccessKey: envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_S3_ACCESS_KEY", true, true),
+			SecretKey: envLoader.GetAndLogEnvVar(envPrefix+"IMAGE_S3_SECRET_KEY", true, true),
+		}
+		if len(s3Config.Region) == 0 {
+			s3Config.Region = "us-east-1"
+		}
+		imageAdapter = image.NewS3ImageAdapter(s3Config, imagesPublicURL, imageRenditions, *logger)
+	case "", "content-bb":
+		imageBaseURL := envLoader.GetAndLogEnvVar(envPrefix+"CONTENT_BB_BASE_URL", true, true)
+		imageAdapter = image.NewImageAdapter(imageBaseURL, imageRenditions, serviceAccountManager, *logger)
+	default:
+		logger.Fatalf("Unknown image storage: %s", imageStorage)
 	}
 
 	// geo bb adapter
 	geoBBGoogleAPIKey := envLoader.GetAndLogEnvVar(envPrefix+"GOOGLE_KEY", true, true)
 	geoBBAdapter := geo.NewGeoBBAdapter(geoBBGoogleAPIKey, logger)
 
+	// web tools feed
+	var webToolsFeed core.WebToolsFeed
+	webToolsFeedDir := envLoader.GetAndLogEnvVar(envPrefix+"WEBTOOLS_FEED_DIR", false, false)
+	if len(webToolsFeedDir) > 0 {
+		//replay the saved pages instead of loading the live feed
+		webToolsFeed, err = webtools.NewDirectoryFeed(webToolsFeedDir)
+		if err != nil {
+			logger.Fatalf("Error initializing web tools directory feed: %v", err)
+		}
+	} else {
+		webToolsHTTPFeed := webtools.NewHTTPFeed(envLoader.GetAndLogEnvVar(envPrefix+"WEBTOOLS_FEED_URL", false, false))
+		webToolsFeed = webToolsHTTPFeed
+
+		webToolsRecordDir := envLoader.GetAndLogEnvVar(envPrefix+"WEBTOOLS_FEED_RECORD_DIR", false, false)
+		if len(webToolsRecordDir) > 0 {
+			webToolsFeed, err = webtools.NewRecorder(webToolsHTTPFeed, webToolsRecordDir)
+			if err != nil {
+				logger.Fatalf("Error initializing web tools feed recorder: %v", err)
+			}
+		}
+	}
+
+	// sidearm athletics feed
+	var sidearmFeed core.SidearmFeed
+	sidearmFeedFile := envLoader.GetAndLogEnvVar(envPrefix+"SIDEARM_FEED_FILE", false, false)
+	if len(sidearmFeedFile) > 0 {
+		//load the saved feed instead of the feed url from the env config
+		sidearmFeed, err = sidearm.NewFileFeed(sidearmFeedFile)
+		if err != nil {
+			logger.Fatalf("Error initializing sidearm file feed: %v", err)
+		}
+	} else {
+		sidearmFeed = sidearm.NewHTTPFeed()
+	}
+
+	// campus walking paths
+	walkingPathsFile := envLoader.GetAndLogEnvVar(envPrefix+"WALKING_PATHS_FILE", false, false)
+	if len(walkingPathsFile) == 0 {
+		walkingPathsFile = "./assets/walking_paths.geojson"
+	}
+	walkingPaths, err := walkingpaths.NewFileSource(walkingPathsFile)
+	if err != nil {
+		logger.Fatalf("Error initializing walking paths: %v", err)
+	}
+
+	// building footprints, the buildings are drawn as points on the maps without them
+	var buildingFootprints core.BuildingFootprints
+	buildingFootprintsFile := envLoader.GetAndLogEnvVar(envPrefix+"BUILDING_FOOTPRINTS_FILE", false, false)
+	if len(buildingFootprintsFile) > 0 {
+		buildingFootprints, err = footprints.NewFileSource(buildingFootprintsFile)
+		if err != nil {
+			logger.Fatalf("Error initializing building footprints: %v", err)
+		}
+	}
+
+	// the org and app the web tools and sidearm feeds events belong to
+	webToolsRegistration := model.WebToolsFeedRegistration{OrgID: envLoader.GetAndLogEnvVar(envPrefix+"WEBTOOLS_ORG_ID", true, false),
+		AppID: envLoader.GetAndLogEnvVar(envPrefix+"WEBTOOLS_APP_ID", true, false)}
+
+	// events retention
+	eventsRetention, err := model.NewLegacyEventsRetention(envLoader.GetAndLogEnvVar(envPrefix+"EVENTS_ARCHIVE_AFTER_DAYS", false, false),
+		envLoader.GetAndLogEnvVar(envPrefix+"EVENTS_PURGE_AFTER_DAYS", false, false))
+	if err != nil {
+		logger.Fatalf("Error parsing events retention: %v", err)
+	}
+
 	// application
 	application := core.NewApplication(Version, Build, storageAdapter, eventsBBAdapter,
-		imageAdapter, geoBBAdapter, appointments, logger)
+		imageAdapter, imageRenditionsBackfill, geoBBAdapter, webToolsFeed, webToolsRegistration, sidearmFeed, walkingPaths, buildingFootprints, appointments, *eventsRetention, logger)
 	err = application.Start()
 	if err != nil {
 		logger.Fatalf("Cannot start the Application module: %v", err)
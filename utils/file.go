@@ -0,0 +1,47 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// DataFile is a local file the gateway loads its data from instead of a remote source, like a saved feed or a GeoJSON extract
+type DataFile struct {
+	path string
+	name string //what the file holds, for the errors
+}
+
+// Read reads the content of the file
+func (f DataFile) Read() ([]byte, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s file: %w", f.name, err)
+	}
+	return data, nil
+}
+
+// NewDataFile creates new data file, the path must be an existing file
+func NewDataFile(path string, name string) (*DataFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s file: %w", name, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s path %s is a directory", name, path)
+	}
+	return &DataFile{path: path, name: name}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewDataFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paths.geojson")
+	err := os.WriteFile(path, []byte(`{"type":"FeatureCollection","features":[]}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "file", path: path},
+		{name: "directory", path: dir, wantErr: true},
+		{name: "missing", path: filepath.Join(dir, "missing.geojson"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := NewDataFile(tt.path, "walking paths")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDataFile() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, err := file.Read()
+			if err != nil || string(data) != `{"type":"FeatureCollection","features":[]}` {
+				t.Errorf("Read() = %s, %v", data, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "math"
+
+// EarthRadius is the mean radius of the Earth in meters
+const EarthRadius = 6371008.8
+
+// GreatCircleDistance gives the distance in meters between two points by the haversine formula
+func GreatCircleDistance(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	lat1, lat2 := latitude1*math.Pi/180, latitude2*math.Pi/180
+	deltaLat := lat2 - lat1
+	deltaLong := (longitude2 - longitude1) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLong/2)*math.Sin(deltaLong/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// InitialBearing gives the direction to follow from the first point to reach the second one
+// in degrees clockwise from the true north, from 0 to 360
+func InitialBearing(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	lat1, lat2 := latitude1*math.Pi/180, latitude2*math.Pi/180
+	deltaLong := (longitude2 - longitude1) * math.Pi / 180
+
+	y := math.Sin(deltaLong) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLong)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+	return math.Mod(bearing+360, 360)
+}
@@ -0,0 +1,317 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// allowedHTMLElements are the elements kept by the sanitizer with the attributes allowed for each of them
+var allowedHTMLElements = map[string]map[string]bool{
+	"a": {"href": true, "title": true}, "p": {}, "br": {}, "hr": {}, "div": {}, "span": {},
+	"b": {}, "strong": {}, "i": {}, "em": {}, "u": {}, "s": {}, "sub": {}, "sup": {}, "small": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"ul": {}, "ol": {}, "li": {}, "blockquote": {}, "pre": {}, "code": {},
+	"table": {}, "thead": {}, "tbody": {}, "tr": {}, "th": {"colspan": true, "rowspan": true}, "td": {"colspan": true, "rowspan": true},
+}
+
+// droppedHTMLElements are the elements removed by the sanitizer together with their content.
+// The other not allowed elements are replaced by their content.
+var droppedHTMLElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true, "embed": true,
+	"applet": true, "noscript": true, "template": true, "head": true, "title": true, "meta": true, "link": true,
+	"base": true, "form": true, "input": true, "button": true, "select": true, "textarea": true, "svg": true, "math": true,
+}
+
+// allowedURLSchemes are the link schemes kept by the sanitizer
+var allowedURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+var (
+	multipleNewLinesRegex = regexp.MustCompile(`\n{3,}`)
+	markdownEscapeRegex   = regexp.MustCompile("([\\\\`*_\\[\\]])")
+)
+
+// HTMLLink represents a link found in HTML content
+type HTMLLink struct {
+	Text string
+	URL  string
+}
+
+// SanitizedHTML represents HTML content made safe for rendering together with its derived formats
+type SanitizedHTML struct {
+	HTML     string
+	Text     string
+	Markdown string
+	Links    []HTMLLink
+}
+
+// SanitizeHTML keeps only the allowed elements, attributes and link schemes of the HTML input.
+// Scripts, styles, event handlers and the other not allowed content are removed.
+// It also gives the content as plain text and as markdown and the links it contains.
+func SanitizeHTML(input string) SanitizedHTML {
+	if len(strings.TrimSpace(input)) == 0 {
+		return SanitizedHTML{}
+	}
+
+	if !strings.Contains(input, "<") {
+		//plain text, so keep its line breaks
+		input = strings.ReplaceAll(html.EscapeString(input), "\n", "<br>")
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(input))
+	if err != nil {
+		//the parser is lenient, so just keep the text without any markup
+		text := strings.Join(strings.Fields(input), " ")
+		return SanitizedHTML{Text: text, Markdown: markdownEscapeRegex.ReplaceAllString(text, `\$1`)}
+	}
+
+	body := doc.Find("body")
+	sanitizeHTMLSelection(body)
+
+	final, err := body.Html()
+	if err != nil {
+		final = ""
+	}
+
+	links := []HTMLLink{}
+	linksURLs := map[string]bool{}
+	body.Find("a[href]").Each(func(_ int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		if linksURLs[href] {
+			return
+		}
+		linksURLs[href] = true
+		links = append(links, HTMLLink{Text: strings.Join(strings.Fields(link.Text()), " "), URL: href})
+	})
+
+	return SanitizedHTML{HTML: strings.TrimSpace(final), Text: renderHTML(body, false), Markdown: renderHTML(body, true), Links: links}
+}
+
+func sanitizeHTMLSelection(selection *goquery.Selection) {
+	selection.Contents().Each(func(_ int, child *goquery.Selection) {
+		name := goquery.NodeName(child)
+		if name == "#text" {
+			return
+		}
+		if strings.HasPrefix(name, "#") || droppedHTMLElements[name] {
+			//comments, doctypes and dropped elements
+			child.Remove()
+			return
+		}
+
+		sanitizeHTMLSelection(child)
+
+		allowedAttributes, allowed := allowedHTMLElements[name]
+		if !allowed {
+			if contents := child.Contents(); contents.Length() > 0 {
+				child.ReplaceWithSelection(contents)
+			} else {
+				child.Remove()
+			}
+			return
+		}
+
+		notAllowed := []string{}
+		for _, attribute := range child.Nodes[0].Attr {
+			if !allowedAttributes[attribute.Key] {
+				notAllowed = append(notAllowed, attribute.Key)
+			}
+		}
+		for _, attribute := range notAllowed {
+			child.RemoveAttr(attribute)
+		}
+		if name == "a" {
+			href, ok := child.Attr("href")
+			if !ok {
+				return
+			}
+			if safeHref := sanitizeHref(href); len(safeHref) > 0 {
+				child.SetAttr("href", safeHref)
+				child.SetAttr("rel", "noopener noreferrer nofollow")
+			} else {
+				child.RemoveAttr("href")
+			}
+		}
+	})
+}
+
+// sanitizeHref gives the href if it is an absolute URL with an allowed scheme, empty string otherwise
+func sanitizeHref(href string) string {
+	href = strings.TrimSpace(href)
+	link, err := url.Parse(href)
+	if err != nil || !allowedURLSchemes[strings.ToLower(link.Scheme)] {
+		return ""
+	}
+	if link.Scheme != "mailto" && len(link.Host) == 0 {
+		return ""
+	}
+	return link.String()
+}
+
+// htmlRenderer renders sanitized HTML as plain text or as markdown
+type htmlRenderer struct {
+	markdown bool
+	builder  strings.Builder
+}
+
+func renderHTML(selection *goquery.Selection, markdown bool) string {
+	renderer := htmlRenderer{markdown: markdown}
+	renderer.renderContents(selection)
+
+	lines := strings.Split(renderer.builder.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+		if markdown && strings.HasSuffix(line, "  ") && len(lines[i]) > 0 {
+			//keep the markdown line break
+			lines[i] += "  "
+		}
+	}
+	result := multipleNewLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(result)
+}
+
+func (r *htmlRenderer) renderContents(selection *goquery.Selection) {
+	selection.Contents().Each(func(_ int, child *goquery.Selection) {
+		r.renderNode(child)
+	})
+}
+
+func (r *htmlRenderer) renderNode(node *goquery.Selection) {
+	name := goquery.NodeName(node)
+	switch name {
+	case "#text":
+		r.writeText(node.Text())
+	case "br":
+		if r.markdown {
+			r.builder.WriteString("  ")
+		}
+		r.builder.WriteString("\n")
+	case "hr":
+		r.paragraph()
+		if r.markdown {
+			r.builder.WriteString("---")
+		}
+		r.paragraph()
+	case "p", "div", "blockquote", "pre", "table", "ul", "ol":
+		r.paragraph()
+		if r.markdown && name == "blockquote" {
+			r.builder.WriteString("> ")
+		}
+		r.renderContents(node)
+		r.paragraph()
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.paragraph()
+		if r.markdown {
+			r.builder.WriteString(strings.Repeat("#", int(name[1]-'0')) + " ")
+		}
+		r.renderContents(node)
+		r.paragraph()
+	case "li":
+		r.newLine()
+		if r.markdown && goquery.NodeName(node.Parent()) == "ol" {
+			r.builder.WriteString("1. ")
+		} else {
+			r.builder.WriteString("- ")
+		}
+		r.renderContents(node)
+		r.newLine()
+	case "tr":
+		r.newLine()
+		r.renderContents(node)
+		r.newLine()
+	case "td", "th":
+		r.renderContents(node)
+		r.builder.WriteString(" ")
+	case "b", "strong":
+		r.renderWrapped(node, "**")
+	case "i", "em":
+		r.renderWrapped(node, "_")
+	case "code":
+		r.renderWrapped(node, "`")
+	case "a":
+		r.renderLink(node)
+	default:
+		r.renderContents(node)
+	}
+}
+
+func (r *htmlRenderer) renderWrapped(node *goquery.Selection, marker string) {
+	if !r.markdown || len(strings.TrimSpace(node.Text())) == 0 {
+		r.renderContents(node)
+		return
+	}
+	r.builder.WriteString(marker)
+	r.renderContents(node)
+	r.builder.WriteString(marker)
+}
+
+func (r *htmlRenderer) renderLink(node *goquery.Selection) {
+	href, _ := node.Attr("href")
+	text := strings.Join(strings.Fields(node.Text()), " ")
+	if len(href) == 0 {
+		r.renderContents(node)
+		return
+	}
+	if len(text) == 0 {
+		text = strings.TrimPrefix(href, "mailto:")
+	}
+
+	if r.markdown {
+		r.builder.WriteString("[" + markdownEscapeRegex.ReplaceAllString(text, `\$1`) + "](" + strings.ReplaceAll(href, ")", "%29") + ")")
+		return
+	}
+	r.builder.WriteString(text)
+	if text != href && text != strings.TrimPrefix(href, "mailto:") {
+		r.builder.WriteString(" (" + href + ")")
+	}
+}
+
+func (r *htmlRenderer) writeText(text string) {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if len(collapsed) == 0 {
+		if len(text) > 0 {
+			r.builder.WriteString(" ")
+		}
+		return
+	}
+	if r.markdown {
+		collapsed = markdownEscapeRegex.ReplaceAllString(collapsed, `\$1`)
+	}
+	if strings.TrimLeft(text, " \t\r\n") != text {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text, " \t\r\n") != text {
+		collapsed += " "
+	}
+	r.builder.WriteString(collapsed)
+}
+
+func (r *htmlRenderer) newLine() {
+	if r.builder.Len() > 0 && !strings.HasSuffix(r.builder.String(), "\n") {
+		r.builder.WriteString("\n")
+	}
+}
+
+func (r *htmlRenderer) paragraph() {
+	r.newLine()
+	if r.builder.Len() > 0 && !strings.HasSuffix(r.builder.String(), "\n\n") {
+		r.builder.WriteString("\n")
+	}
+}
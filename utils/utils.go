@@ -22,8 +22,6 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/PuerkitoBio/goquery"
 )
 
 // Filter represents find filter for finding entities by the their fields
@@ -55,65 +53,6 @@ func ConstructFilter(r *http.Request) *Filter {
 	return &filter
 }
 
-// ModifyHTMLContent removes all not web href links. It also remove web links which points to pdf document
-// For example:
-// <a href="mailto:email@abc.abc">email@abc.abc</a> -> email@abc.abc
-// <a href="ftp://server/file">Some text</a> -> Some text
-// <a href="tel:1234">1234</a> -> 1234
-//
-// <a href="https://humanresources.illinois.edu/assets/docs/COVID-19-Pay-Continuation-Protocol-Final-3-22-2020.pdf">the university's pay continuation protocol</a> ->
-// the university's pay continuation protocol(https://humanresources.illinois.edu/assets/docs/COVID-19-Pay-Continuation-Protocol-Final-3-22-2020.pdf)
-func ModifyHTMLContent(input string) string {
-	reader := strings.NewReader(input)
-	doc, err := goquery.NewDocumentFromReader(reader)
-	if err != nil {
-		log.Printf("error creating reader from the html string - %s\n", err)
-		//there is no what to do so return the input
-		return input
-	}
-
-	//process
-	doc.Find("a").Each(func(_ int, link *goquery.Selection) {
-		text := strings.TrimSpace(link.Text())
-		href, ok := link.Attr("href")
-		if ok && len(href) > 0 {
-
-			splitHref := strings.Split(href, ":")
-			if len(splitHref) > 0 {
-				protocol := splitHref[0]
-
-				if protocol == "http" || protocol == "https" {
-					//it is a web protocol, so we just need to look for .pdf resources
-					if strings.HasSuffix(href, ".pdf") {
-						log.Printf("modifying.. href - %s\ttext - %s\n", href, text)
-						link.ReplaceWithHtml(text + "(" + href + ")")
-					}
-				} else {
-					//it is not а web protocol, so here we need to apply modifications
-
-					log.Printf("modifying.. href - %s\ttext - %s\n", href, text)
-					link.ReplaceWithHtml(text)
-				}
-			}
-
-		}
-	})
-
-	body := doc.Find("body")
-	if body == nil {
-		log.Printf("body is nil for some reasons - %s\n", input)
-		//there is no what to do so return the input
-		return input
-	}
-	final, err := body.Html()
-	if err != nil {
-		log.Printf("error getting html from body - %s\n", err)
-		//there is no what to do so return the input
-		return input
-	}
-	return final
-}
-
 // LogRequest logs the request as hide some header fields because of security reasons
 func LogRequest(req *http.Request) {
 	if req == nil {